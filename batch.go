@@ -0,0 +1,166 @@
+package ces
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+)
+
+type (
+	// BatchItemError is the error of parsing one execution result of the batch
+	BatchItemError struct {
+		// Index is the position of the execution result in the batch
+		Index int
+		Err   error
+	}
+
+	// BatchParseError aggregates errors of all failed execution results of the batch ordered by the index
+	BatchParseError struct {
+		Errors []*BatchItemError
+	}
+)
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("execution result %d: %s", e.Index, e.Err)
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+func (e *BatchParseError) Error() string {
+	failed := make([]string, 0, len(e.Errors))
+	for _, one := range e.Errors {
+		failed = append(failed, one.Error())
+	}
+	return fmt.Sprintf("error: failed to parse %d execution results: %s", len(e.Errors), strings.Join(failed, "; "))
+}
+
+// Unwrap return errors of all failed items, errors.Is matches any of them, e.g. ErrFailedDeploy
+func (e *BatchParseError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, one := range e.Errors {
+		errs = append(errs, one)
+	}
+	return errs
+}
+
+// ParseExecutionResultsBatch parse execution results with the pool of workers, see WithBatchWorkers.
+// The result has the same length and order as executionResults, failed items have nil results and are reported
+// with *BatchParseError. The batch is split at execution results rewriting __events_schema of an observed contract:
+// the items before the rewrite are parsed with the old schemas, the rewriting item is parsed alone and the later
+// items with the new schemas, so the result does not depend on the order the workers run.
+func (p *EventParser) ParseExecutionResultsBatch(ctx context.Context, executionResults []casper.ExecutionResult) ([][]ParseResult, error) {
+	var (
+		results = make([][]ParseResult, len(executionResults))
+		errs    = make([]error, len(executionResults))
+		start   int
+	)
+
+	schemaURefs := p.observedSchemaURefs()
+	for idx, executionResult := range executionResults {
+		if !rewritesSchema(executionResult, schemaURefs) {
+			continue
+		}
+
+		if err := p.parseBatchSegment(ctx, executionResults, start, idx, results, errs); err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		results[idx], errs[idx] = p.ParseExecutionResults(executionResult)
+		start = idx + 1
+	}
+
+	if err := p.parseBatchSegment(ctx, executionResults, start, len(executionResults), results, errs); err != nil {
+		return nil, err
+	}
+
+	var batchErr BatchParseError
+	for idx, err := range errs {
+		if err != nil {
+			batchErr.Errors = append(batchErr.Errors, &BatchItemError{Index: idx, Err: err})
+		}
+	}
+
+	if len(batchErr.Errors) > 0 {
+		return results, &batchErr
+	}
+
+	return results, nil
+}
+
+// parseBatchSegment parse execution results from the from index up to the to index with the pool of workers,
+// the segment must not rewrite schemas of the observed contracts
+func (p *EventParser) parseBatchSegment(ctx context.Context, executionResults []casper.ExecutionResult, from, to int, results [][]ParseResult, errs []error) error {
+	workers := p.batchWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > to-from {
+		workers = to - from
+	}
+
+	var (
+		jobs = make(chan int)
+		wg   sync.WaitGroup
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx], errs[idx] = p.ParseExecutionResults(executionResults[idx])
+			}
+		}()
+	}
+
+dispatch:
+	for idx := from; idx < to; idx++ {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case jobs <- idx:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// observedSchemaURefs return the __events_schema URefs index, it is empty if the schema refresh is turned off.
+// The index is not changed by schema rewrites and is not copied.
+func (p *EventParser) observedSchemaURefs() map[casper.Uref]string {
+	if p.schemaRefreshOff {
+		return nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.schemaURefs
+}
+
+// rewritesSchema check the successful execution result writes any of the schema URefs
+func rewritesSchema(executionResult casper.ExecutionResult, schemaURefs map[casper.Uref]string) bool {
+	if executionResult.ErrorMessage != nil {
+		return false
+	}
+
+	for _, transform := range executionResult.Effects {
+		if transform.Key.URef == nil || !transform.Kind.IsWriteCLValue() {
+			continue
+		}
+		if _, ok := schemaURefs[*transform.Key.URef]; ok {
+			return true
+		}
+	}
+	return false
+}
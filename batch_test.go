@@ -0,0 +1,98 @@
+package ces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExecutionResultsBatch(t *testing.T) {
+	metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	eventParser := EventParser{
+		contractsMetadata: map[string]ContractMetadata{metadata.EventsURef.String(): metadata},
+		batchWorkers:      3,
+	}
+
+	data, err := os.ReadFile("./utils/fixtures/deploys/voting_created.json")
+	require.NoError(t, err)
+
+	var fixture struct {
+		ExecutionResults []types.DeployExecutionResult `json:"execution_results"`
+	}
+	require.NoError(t, json.Unmarshal(data, &fixture))
+	executionResult := types.DeployExecutionInfoFromV1(fixture.ExecutionResults, nil).ExecutionResult
+
+	failedMessage := "User error: 1"
+	failed := casper.ExecutionResult{ErrorMessage: &failedMessage}
+
+	batch := []casper.ExecutionResult{executionResult, failed, executionResult, {}, failed, executionResult}
+
+	results, err := eventParser.ParseExecutionResultsBatch(context.Background(), batch)
+	require.Len(t, results, len(batch))
+
+	var batchErr *BatchParseError
+	require.ErrorAs(t, err, &batchErr)
+	assert.ErrorIs(t, err, ErrFailedDeploy)
+	require.Len(t, batchErr.Errors, 2)
+	assert.Equal(t, 1, batchErr.Errors[0].Index)
+	assert.Equal(t, 4, batchErr.Errors[1].Index)
+
+	for _, idx := range []int{0, 2, 5} {
+		require.Len(t, results[idx], 2)
+		assert.Equal(t, "BallotCast", results[idx][0].Event.Name)
+		assert.Equal(t, "SimpleVotingCreated", results[idx][1].Event.Name)
+	}
+	assert.Nil(t, results[1])
+	assert.Empty(t, results[3])
+
+	t.Run("Schema rewrite in the middle", func(t *testing.T) {
+		// new schema contains the only event Mint(amount: U256)
+		const newSchemaHex = "01000000040000004d696e740100000006000000616d6f756e7407"
+
+		var transforms []casper.Transform
+		err := json.Unmarshal([]byte(fmt.Sprintf(`[
+			{"key": "%s", "kind": {"WriteCLValue": {"cl_type": "Any", "bytes": "%s"}}}
+		]`, metadata.EventsSchemaURef.String(), newSchemaHex)), &transforms)
+		require.NoError(t, err)
+
+		rewrite := casper.ExecutionResult{Effects: transforms}
+		batch := []casper.ExecutionResult{executionResult, executionResult, executionResult, rewrite, executionResult, executionResult}
+
+		// the items are split at the rewrite, so the result is the same whatever order the workers run
+		for i := 0; i < 20; i++ {
+			eventParser := EventParser{batchWorkers: 3}
+			eventParser.setContractsMetadata(map[string]ContractMetadata{metadata.EventsURef.String(): metadata})
+
+			results, err := eventParser.ParseExecutionResultsBatch(context.Background(), batch)
+			require.NoError(t, err)
+			require.Len(t, results, len(batch))
+
+			for _, idx := range []int{0, 1, 2} {
+				require.Len(t, results[idx], 2)
+				assert.NoError(t, results[idx][0].Error)
+				assert.NoError(t, results[idx][1].Error)
+			}
+			assert.Empty(t, results[3])
+			for _, idx := range []int{4, 5} {
+				require.Len(t, results[idx], 2)
+				assert.ErrorIs(t, results[idx][0].Error, ErrEventNameNotInSchema)
+				assert.ErrorIs(t, results[idx][1].Error, ErrEventNameNotInSchema)
+			}
+		}
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := eventParser.ParseExecutionResultsBatch(ctx, batch)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,172 @@
+// Package cep18 provides typed CEP-18 fungible token events decoded from CES events and the balance ledger
+package cep18
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+
+	"github.com/make-software/ces-go-parser/v2"
+	"github.com/make-software/ces-go-parser/v2/internal/eventreader"
+)
+
+var (
+	ErrUnexpectedEventName   = errors.New("error: unexpected CEP-18 event name")
+	ErrUnexpectedEventSchema = errors.New("error: event data does not match CEP-18 event schema")
+)
+
+const (
+	MintEventName              = "Mint"
+	BurnEventName              = "Burn"
+	TransferEventName          = "Transfer"
+	TransferFromEventName      = "TransferFrom"
+	SetAllowanceEventName      = "SetAllowance"
+	IncreaseAllowanceEventName = "IncreaseAllowance"
+	DecreaseAllowanceEventName = "DecreaseAllowance"
+)
+
+type (
+	Mint struct {
+		Recipient casper.Key
+		Amount    *big.Int
+	}
+
+	Burn struct {
+		Owner  casper.Key
+		Amount *big.Int
+	}
+
+	Transfer struct {
+		Sender    casper.Key
+		Recipient casper.Key
+		Amount    *big.Int
+	}
+
+	TransferFrom struct {
+		Spender   casper.Key
+		Owner     casper.Key
+		Recipient casper.Key
+		Amount    *big.Int
+	}
+
+	SetAllowance struct {
+		Owner     casper.Key
+		Spender   casper.Key
+		Allowance *big.Int
+	}
+
+	// IncreaseAllowance carries the resulting Allowance together with the increment
+	IncreaseAllowance struct {
+		Owner     casper.Key
+		Spender   casper.Key
+		Allowance *big.Int
+		IncBy     *big.Int
+	}
+
+	// DecreaseAllowance carries the resulting Allowance together with the decrement
+	DecreaseAllowance struct {
+		Owner     casper.Key
+		Spender   casper.Key
+		Allowance *big.Int
+		DecrBy    *big.Int
+	}
+)
+
+// ParseEvent convert CES event to one of the typed CEP-18 events
+func ParseEvent(event ces.Event) (interface{}, error) {
+	switch event.Name {
+	case MintEventName:
+		return ParseMint(event)
+	case BurnEventName:
+		return ParseBurn(event)
+	case TransferEventName:
+		return ParseTransfer(event)
+	case TransferFromEventName:
+		return ParseTransferFrom(event)
+	case SetAllowanceEventName:
+		return ParseSetAllowance(event)
+	case IncreaseAllowanceEventName:
+		return ParseIncreaseAllowance(event)
+	case DecreaseAllowanceEventName:
+		return ParseDecreaseAllowance(event)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedEventName, event.Name)
+	}
+}
+
+func ParseMint(event ces.Event) (Mint, error) {
+	reader := newFieldReader(event, MintEventName)
+	result := Mint{
+		Recipient: reader.Key("recipient"),
+		Amount:    reader.UInt256("amount"),
+	}
+	return result, reader.Finish()
+}
+
+func ParseBurn(event ces.Event) (Burn, error) {
+	reader := newFieldReader(event, BurnEventName)
+	result := Burn{
+		Owner:  reader.Key("owner"),
+		Amount: reader.UInt256("amount"),
+	}
+	return result, reader.Finish()
+}
+
+func ParseTransfer(event ces.Event) (Transfer, error) {
+	reader := newFieldReader(event, TransferEventName)
+	result := Transfer{
+		Sender:    reader.Key("sender"),
+		Recipient: reader.Key("recipient"),
+		Amount:    reader.UInt256("amount"),
+	}
+	return result, reader.Finish()
+}
+
+func ParseTransferFrom(event ces.Event) (TransferFrom, error) {
+	reader := newFieldReader(event, TransferFromEventName)
+	result := TransferFrom{
+		Spender:   reader.Key("spender"),
+		Owner:     reader.Key("owner"),
+		Recipient: reader.Key("recipient"),
+		Amount:    reader.UInt256("amount"),
+	}
+	return result, reader.Finish()
+}
+
+func ParseSetAllowance(event ces.Event) (SetAllowance, error) {
+	reader := newFieldReader(event, SetAllowanceEventName)
+	result := SetAllowance{
+		Owner:     reader.Key("owner"),
+		Spender:   reader.Key("spender"),
+		Allowance: reader.UInt256("allowance"),
+	}
+	return result, reader.Finish()
+}
+
+func ParseIncreaseAllowance(event ces.Event) (IncreaseAllowance, error) {
+	reader := newFieldReader(event, IncreaseAllowanceEventName)
+	result := IncreaseAllowance{
+		Owner:     reader.Key("owner"),
+		Spender:   reader.Key("spender"),
+		Allowance: reader.UInt256("allowance"),
+		IncBy:     reader.UInt256("inc_by"),
+	}
+	return result, reader.Finish()
+}
+
+func ParseDecreaseAllowance(event ces.Event) (DecreaseAllowance, error) {
+	reader := newFieldReader(event, DecreaseAllowanceEventName)
+	result := DecreaseAllowance{
+		Owner:     reader.Key("owner"),
+		Spender:   reader.Key("spender"),
+		Allowance: reader.UInt256("allowance"),
+		DecrBy:    reader.UInt256("decr_by"),
+	}
+	return result, reader.Finish()
+}
+
+func newFieldReader(event ces.Event, expectedName string) *eventreader.Reader {
+	return eventreader.New(event, expectedName, ErrUnexpectedEventName, ErrUnexpectedEventSchema)
+}
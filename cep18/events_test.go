@@ -0,0 +1,58 @@
+package cep18
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue"
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2"
+)
+
+const (
+	aliceKey = "account-hash-e07ca98a6ecfd5c4e8e3b9e3fb2dff0d6e0aab7e4c37d6d1f7d5ee3a8bd2d0e1"
+	bobKey   = "account-hash-a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"
+)
+
+func newKey(t *testing.T, source string) casper.Key {
+	key, err := casper.NewKey(source)
+	require.NoError(t, err)
+	return key
+}
+
+func keyValue(key casper.Key) casper.CLValue {
+	return casper.CLValue{Type: cltype.Key, Key: &key}
+}
+
+func u256Value(value int64) casper.CLValue {
+	return clvalue.NewCLUInt256(big.NewInt(value))
+}
+
+func TestParseEvent(t *testing.T) {
+	alice, bob := newKey(t, aliceKey), newKey(t, bobKey)
+
+	result, err := ParseEvent(ces.Event{Name: "TransferFrom", Data: map[string]casper.CLValue{
+		"spender":   keyValue(bob),
+		"owner":     keyValue(alice),
+		"recipient": keyValue(bob),
+		"amount":    u256Value(15),
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, TransferFrom{Spender: bob, Owner: alice, Recipient: bob, Amount: big.NewInt(15)}, result)
+
+	_, err = ParseMint(ces.Event{Name: "Mint", Data: map[string]casper.CLValue{
+		"recipient": keyValue(alice),
+		"amount":    clvalue.NewCLString("15"),
+	}})
+	assert.ErrorIs(t, err, ErrUnexpectedEventSchema)
+
+	_, err = ParseBurn(ces.Event{Name: "Mint"})
+	assert.ErrorIs(t, err, ErrUnexpectedEventName)
+
+	_, err = ParseEvent(ces.Event{Name: "ChangeSecurity"})
+	assert.ErrorIs(t, err, ErrUnexpectedEventName)
+}
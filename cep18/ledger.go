@@ -0,0 +1,226 @@
+package cep18
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+
+	"github.com/make-software/ces-go-parser/v2"
+)
+
+var (
+	ErrInsufficientBalance   = errors.New("error: insufficient balance")
+	ErrInsufficientAllowance = errors.New("error: insufficient allowance")
+)
+
+type (
+	// Ledger projects CEP-18 events into balances, allowances and total supply per contract.
+	// Events must be applied in the event id order, events with id not greater than the last applied one are skipped,
+	// so replaying the same history is idempotent.
+	Ledger struct {
+		mu        sync.RWMutex
+		contracts map[casper.Hash]*contractLedger
+	}
+
+	contractLedger struct {
+		lastEventID uint
+		applied     bool
+		totalSupply *big.Int
+		balances    map[string]*big.Int
+		allowances  map[allowanceKey]*big.Int
+	}
+
+	allowanceKey struct {
+		owner   string
+		spender string
+	}
+)
+
+func NewLedger() *Ledger {
+	return &Ledger{
+		contracts: make(map[casper.Hash]*contractLedger),
+	}
+}
+
+// Apply update the contract state with the event, events of other standards are ignored.
+// The state is not changed if the event is rejected with an error.
+func (l *Ledger) Apply(event ces.Event) error {
+	parsed, err := ParseEvent(event)
+	if errors.Is(err, ErrUnexpectedEventName) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	contract, ok := l.contracts[event.ContractHash]
+	if !ok {
+		contract = &contractLedger{
+			totalSupply: new(big.Int),
+			balances:    make(map[string]*big.Int),
+			allowances:  make(map[allowanceKey]*big.Int),
+		}
+		l.contracts[event.ContractHash] = contract
+	}
+
+	if contract.applied && event.EventID <= contract.lastEventID {
+		return nil
+	}
+
+	if err = contract.apply(parsed); err != nil {
+		return fmt.Errorf("contract %s event %d: %w", event.ContractHash.ToHex(), event.EventID, err)
+	}
+
+	contract.applied = true
+	contract.lastEventID = event.EventID
+	return nil
+}
+
+// Reset drop the contract state to replay its history from the beginning
+func (l *Ledger) Reset(contractHash casper.Hash) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.contracts, contractHash)
+}
+
+// LastEventID return id of the last applied event of the contract, false if no events were applied
+func (l *Ledger) LastEventID(contractHash casper.Hash) (uint, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	contract, ok := l.contracts[contractHash]
+	if !ok || !contract.applied {
+		return 0, false
+	}
+	return contract.lastEventID, true
+}
+
+func (l *Ledger) TotalSupply(contractHash casper.Hash) *big.Int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	contract, ok := l.contracts[contractHash]
+	if !ok {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(contract.totalSupply)
+}
+
+func (l *Ledger) Balance(contractHash casper.Hash, account casper.Key) *big.Int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	contract, ok := l.contracts[contractHash]
+	if !ok {
+		return new(big.Int)
+	}
+	return copyOrZero(contract.balances[account.String()])
+}
+
+// Balances return non-zero balances of the contract keyed by the account key string
+func (l *Ledger) Balances(contractHash casper.Hash) map[string]*big.Int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	result := make(map[string]*big.Int)
+	contract, ok := l.contracts[contractHash]
+	if !ok {
+		return result
+	}
+
+	for account, balance := range contract.balances {
+		result[account] = new(big.Int).Set(balance)
+	}
+	return result
+}
+
+func (l *Ledger) Allowance(contractHash casper.Hash, owner, spender casper.Key) *big.Int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	contract, ok := l.contracts[contractHash]
+	if !ok {
+		return new(big.Int)
+	}
+	return copyOrZero(contract.allowances[allowanceKey{owner: owner.String(), spender: spender.String()}])
+}
+
+func (c *contractLedger) apply(event interface{}) error {
+	switch one := event.(type) {
+	case Mint:
+		c.add(one.Recipient, one.Amount)
+		c.totalSupply.Add(c.totalSupply, one.Amount)
+	case Burn:
+		if err := c.checkBalance(one.Owner, one.Amount); err != nil {
+			return err
+		}
+		c.add(one.Owner, new(big.Int).Neg(one.Amount))
+		c.totalSupply.Sub(c.totalSupply, one.Amount)
+	case Transfer:
+		if err := c.checkBalance(one.Sender, one.Amount); err != nil {
+			return err
+		}
+		c.add(one.Sender, new(big.Int).Neg(one.Amount))
+		c.add(one.Recipient, one.Amount)
+	case TransferFrom:
+		key := allowanceKey{owner: one.Owner.String(), spender: one.Spender.String()}
+		allowance := copyOrZero(c.allowances[key])
+		if allowance.Cmp(one.Amount) < 0 {
+			return ErrInsufficientAllowance
+		}
+		if err := c.checkBalance(one.Owner, one.Amount); err != nil {
+			return err
+		}
+		c.setAllowance(key, allowance.Sub(allowance, one.Amount))
+		c.add(one.Owner, new(big.Int).Neg(one.Amount))
+		c.add(one.Recipient, one.Amount)
+	case SetAllowance:
+		c.setAllowance(allowanceKey{owner: one.Owner.String(), spender: one.Spender.String()}, one.Allowance)
+	case IncreaseAllowance:
+		c.setAllowance(allowanceKey{owner: one.Owner.String(), spender: one.Spender.String()}, one.Allowance)
+	case DecreaseAllowance:
+		c.setAllowance(allowanceKey{owner: one.Owner.String(), spender: one.Spender.String()}, one.Allowance)
+	}
+
+	return nil
+}
+
+func (c *contractLedger) checkBalance(account casper.Key, amount *big.Int) error {
+	if copyOrZero(c.balances[account.String()]).Cmp(amount) < 0 {
+		return ErrInsufficientBalance
+	}
+	return nil
+}
+
+// add change the account balance by the amount and drop zero balances
+func (c *contractLedger) add(account casper.Key, amount *big.Int) {
+	balance := copyOrZero(c.balances[account.String()])
+	balance.Add(balance, amount)
+	if balance.Sign() == 0 {
+		delete(c.balances, account.String())
+		return
+	}
+	c.balances[account.String()] = balance
+}
+
+func (c *contractLedger) setAllowance(key allowanceKey, allowance *big.Int) {
+	if allowance.Sign() == 0 {
+		delete(c.allowances, key)
+		return
+	}
+	c.allowances[key] = new(big.Int).Set(allowance)
+}
+
+func copyOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(value)
+}
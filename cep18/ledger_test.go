@@ -0,0 +1,64 @@
+package cep18
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2"
+)
+
+func TestLedger(t *testing.T) {
+	alice, bob := newKey(t, aliceKey), newKey(t, bobKey)
+	contractHash, err := casper.NewHash("0640eb43bd95d5c88b799862bc9fb42d7a241f1a8aae5deaa03170a27ee8eeaa")
+	require.NoError(t, err)
+
+	history := []ces.Event{
+		{Name: "Mint", Data: map[string]casper.CLValue{"recipient": keyValue(alice), "amount": u256Value(100)}},
+		{Name: "Transfer", Data: map[string]casper.CLValue{"sender": keyValue(alice), "recipient": keyValue(bob), "amount": u256Value(30)}},
+		{Name: "SetAllowance", Data: map[string]casper.CLValue{"owner": keyValue(alice), "spender": keyValue(bob), "allowance": u256Value(20)}},
+		{Name: "IncreaseAllowance", Data: map[string]casper.CLValue{"owner": keyValue(alice), "spender": keyValue(bob), "allowance": u256Value(25), "inc_by": u256Value(5)}},
+		{Name: "TransferFrom", Data: map[string]casper.CLValue{"spender": keyValue(bob), "owner": keyValue(alice), "recipient": keyValue(bob), "amount": u256Value(10)}},
+		{Name: "ChangeSecurity"},
+		{Name: "Burn", Data: map[string]casper.CLValue{"owner": keyValue(bob), "amount": u256Value(40)}},
+	}
+	for i := range history {
+		history[i].ContractHash = contractHash
+		history[i].EventID = uint(i)
+	}
+
+	ledger := NewLedger()
+	// the second replay of the same history is skipped
+	for i := 0; i < 2; i++ {
+		for _, event := range history {
+			require.NoError(t, ledger.Apply(event))
+		}
+	}
+
+	assert.Equal(t, big.NewInt(60), ledger.TotalSupply(contractHash))
+	assert.Equal(t, big.NewInt(60), ledger.Balance(contractHash, alice))
+	assert.Equal(t, big.NewInt(0), ledger.Balance(contractHash, bob))
+	assert.Equal(t, big.NewInt(15), ledger.Allowance(contractHash, alice, bob))
+	assert.Equal(t, map[string]*big.Int{alice.String(): big.NewInt(60)}, ledger.Balances(contractHash))
+
+	lastEventID, ok := ledger.LastEventID(contractHash)
+	require.True(t, ok)
+	assert.Equal(t, uint(6), lastEventID)
+
+	overdraft := ces.Event{
+		ContractHash: contractHash,
+		EventID:      7,
+		Name:         "Transfer",
+		Data:         map[string]casper.CLValue{"sender": keyValue(bob), "recipient": keyValue(alice), "amount": u256Value(1)},
+	}
+	assert.ErrorIs(t, ledger.Apply(overdraft), ErrInsufficientBalance)
+	assert.Equal(t, big.NewInt(60), ledger.Balance(contractHash, alice))
+
+	ledger.Reset(contractHash)
+	assert.Equal(t, big.NewInt(0), ledger.TotalSupply(contractHash))
+	_, ok = ledger.LastEventID(contractHash)
+	assert.False(t, ok)
+}
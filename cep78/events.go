@@ -0,0 +1,201 @@
+// Package cep78 provides typed CEP-78 NFT events decoded from CES events
+package cep78
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+
+	"github.com/make-software/ces-go-parser/v2"
+	"github.com/make-software/ces-go-parser/v2/internal/eventreader"
+)
+
+var (
+	ErrUnexpectedEventName   = errors.New("error: unexpected CEP-78 event name")
+	ErrUnexpectedEventSchema = errors.New("error: event data does not match CEP-78 event schema")
+)
+
+const (
+	MintEventName            = "Mint"
+	BurnEventName            = "Burn"
+	ApprovalEventName        = "Approval"
+	ApprovalRevokedEventName = "ApprovalRevoked"
+	ApprovalForAllEventName  = "ApprovalForAll"
+	RevokedForAllEventName   = "RevokedForAll"
+	TransferEventName        = "Transfer"
+	MetadataUpdatedEventName = "MetadataUpdated"
+	MigrationEventName       = "Migration"
+	VariablesSetEventName    = "VariablesSet"
+)
+
+type (
+	Mint struct {
+		Recipient casper.Key
+		TokenID   TokenID
+		Data      string
+	}
+
+	Burn struct {
+		Owner   casper.Key
+		TokenID TokenID
+		// Burner is nil for releases emitting Burn without the burner field
+		Burner *casper.Key
+	}
+
+	Approval struct {
+		Owner casper.Key
+		// Spender is read from the operator field for releases before it was renamed
+		Spender casper.Key
+		TokenID TokenID
+	}
+
+	ApprovalRevoked struct {
+		Owner   casper.Key
+		TokenID TokenID
+	}
+
+	ApprovalForAll struct {
+		Owner    casper.Key
+		Operator casper.Key
+	}
+
+	RevokedForAll struct {
+		Owner    casper.Key
+		Operator casper.Key
+	}
+
+	Transfer struct {
+		Owner casper.Key
+		// Spender is nil if the token was transferred by the owner
+		Spender   *casper.Key
+		Recipient casper.Key
+		TokenID   TokenID
+	}
+
+	MetadataUpdated struct {
+		TokenID TokenID
+		Data    string
+	}
+
+	Migration struct{}
+
+	VariablesSet struct{}
+)
+
+// ParseEvent convert CES event to one of the typed CEP-78 events, token identifiers are read according to
+// the identifier mode of the contract, see NewTokenIDKind
+func ParseEvent(event ces.Event, kind TokenIDKind) (interface{}, error) {
+	switch event.Name {
+	case MintEventName:
+		return ParseMint(event, kind)
+	case BurnEventName:
+		return ParseBurn(event, kind)
+	case ApprovalEventName:
+		return ParseApproval(event, kind)
+	case ApprovalRevokedEventName:
+		return ParseApprovalRevoked(event, kind)
+	case ApprovalForAllEventName:
+		return ParseApprovalForAll(event)
+	case RevokedForAllEventName:
+		return ParseRevokedForAll(event)
+	case TransferEventName:
+		return ParseTransfer(event, kind)
+	case MetadataUpdatedEventName:
+		return ParseMetadataUpdated(event, kind)
+	case MigrationEventName:
+		return Migration{}, newFieldReader(event, MigrationEventName).Finish()
+	case VariablesSetEventName:
+		return VariablesSet{}, newFieldReader(event, VariablesSetEventName).Finish()
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedEventName, event.Name)
+	}
+}
+
+func ParseMint(event ces.Event, kind TokenIDKind) (Mint, error) {
+	reader := newFieldReader(event, MintEventName)
+	result := Mint{
+		Recipient: reader.Key("recipient"),
+		TokenID:   readTokenID(reader, "token_id", kind),
+		Data:      reader.String("data"),
+	}
+	return result, reader.Finish()
+}
+
+func ParseBurn(event ces.Event, kind TokenIDKind) (Burn, error) {
+	reader := newFieldReader(event, BurnEventName)
+	result := Burn{
+		Owner:   reader.Key("owner"),
+		TokenID: readTokenID(reader, "token_id", kind),
+	}
+	if reader.Has("burner") {
+		burner := reader.Key("burner")
+		result.Burner = &burner
+	}
+	return result, reader.Finish()
+}
+
+func ParseApproval(event ces.Event, kind TokenIDKind) (Approval, error) {
+	reader := newFieldReader(event, ApprovalEventName)
+	result := Approval{
+		Owner:   reader.Key("owner"),
+		TokenID: readTokenID(reader, "token_id", kind),
+	}
+	if reader.Has("operator") {
+		result.Spender = reader.Key("operator")
+	} else {
+		result.Spender = reader.Key("spender")
+	}
+	return result, reader.Finish()
+}
+
+func ParseApprovalRevoked(event ces.Event, kind TokenIDKind) (ApprovalRevoked, error) {
+	reader := newFieldReader(event, ApprovalRevokedEventName)
+	result := ApprovalRevoked{
+		Owner:   reader.Key("owner"),
+		TokenID: readTokenID(reader, "token_id", kind),
+	}
+	return result, reader.Finish()
+}
+
+func ParseApprovalForAll(event ces.Event) (ApprovalForAll, error) {
+	reader := newFieldReader(event, ApprovalForAllEventName)
+	result := ApprovalForAll{
+		Owner:    reader.Key("owner"),
+		Operator: reader.Key("operator"),
+	}
+	return result, reader.Finish()
+}
+
+func ParseRevokedForAll(event ces.Event) (RevokedForAll, error) {
+	reader := newFieldReader(event, RevokedForAllEventName)
+	result := RevokedForAll{
+		Owner:    reader.Key("owner"),
+		Operator: reader.Key("operator"),
+	}
+	return result, reader.Finish()
+}
+
+func ParseTransfer(event ces.Event, kind TokenIDKind) (Transfer, error) {
+	reader := newFieldReader(event, TransferEventName)
+	result := Transfer{
+		Owner:     reader.Key("owner"),
+		Spender:   reader.OptionalKey("spender"),
+		Recipient: reader.Key("recipient"),
+		TokenID:   readTokenID(reader, "token_id", kind),
+	}
+	return result, reader.Finish()
+}
+
+func ParseMetadataUpdated(event ces.Event, kind TokenIDKind) (MetadataUpdated, error) {
+	reader := newFieldReader(event, MetadataUpdatedEventName)
+	result := MetadataUpdated{
+		TokenID: readTokenID(reader, "token_id", kind),
+		Data:    reader.String("data"),
+	}
+	return result, reader.Finish()
+}
+
+func newFieldReader(event ces.Event, expectedName string) *eventreader.Reader {
+	return eventreader.New(event, expectedName, ErrUnexpectedEventName, ErrUnexpectedEventSchema)
+}
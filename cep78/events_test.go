@@ -0,0 +1,137 @@
+package cep78
+
+import (
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue"
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2"
+)
+
+const tokenHash = "B6AF33EEA0A3D1CC7E5E1B6B1E4B2E6C3A0D0F9E8C7B6A5948372615F4E3D2C1"
+
+func newKeyValue(t *testing.T, source string) (casper.Key, casper.CLValue) {
+	key, err := casper.NewKey(source)
+	require.NoError(t, err)
+	return key, casper.CLValue{Type: cltype.Key, Key: &key}
+}
+
+func TestParseEvent(t *testing.T) {
+	owner, ownerValue := newKeyValue(t, "account-hash-e07ca98a6ecfd5c4e8e3b9e3fb2dff0d6e0aab7e4c37d6d1f7d5ee3a8bd2d0e1")
+	recipient, recipientValue := newKeyValue(t, "account-hash-a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90")
+
+	t.Run("Mint with ordinal token id", func(t *testing.T) {
+		result, err := ParseEvent(ces.Event{Name: "Mint", Data: map[string]casper.CLValue{
+			"recipient": recipientValue,
+			"token_id":  clvalue.NewCLString("42"),
+			"data":      clvalue.NewCLString(`{"name":"token"}`),
+		}}, TokenIDIndex)
+		require.NoError(t, err)
+		assert.Equal(t, Mint{Recipient: recipient, TokenID: NewTokenIndex(42), Data: `{"name":"token"}`}, result)
+	})
+
+	t.Run("Burn before burner field", func(t *testing.T) {
+		burn, err := ParseBurn(ces.Event{Name: "Burn", Data: map[string]casper.CLValue{
+			"owner":    ownerValue,
+			"token_id": clvalue.NewCLString(tokenHash),
+		}}, TokenIDHash)
+		require.NoError(t, err)
+		assert.Equal(t, owner, burn.Owner)
+		assert.Nil(t, burn.Burner)
+		assert.True(t, burn.TokenID.IsHash())
+		assert.Equal(t, "b6af33eea0a3d1cc7e5e1b6b1e4b2e6c3a0d0f9e8c7b6a5948372615f4e3d2c1", burn.TokenID.String())
+	})
+
+	t.Run("Approval with operator field", func(t *testing.T) {
+		approval, err := ParseApproval(ces.Event{Name: "Approval", Data: map[string]casper.CLValue{
+			"owner":    ownerValue,
+			"operator": recipientValue,
+			"token_id": clvalue.NewCLString("1"),
+		}}, TokenIDIndex)
+		require.NoError(t, err)
+		assert.Equal(t, recipient, approval.Spender)
+	})
+
+	t.Run("Transfer", func(t *testing.T) {
+		transfer, err := ParseTransfer(ces.Event{Name: "Transfer", Data: map[string]casper.CLValue{
+			"owner":     ownerValue,
+			"spender":   {Type: &cltype.Option{Inner: cltype.Key}, Option: &clvalue.Option{Inner: &recipientValue}},
+			"recipient": recipientValue,
+			"token_id":  clvalue.NewCLString("7"),
+		}}, TokenIDIndex)
+		require.NoError(t, err)
+		require.NotNil(t, transfer.Spender)
+		assert.Equal(t, recipient, *transfer.Spender)
+
+		transfer, err = ParseTransfer(ces.Event{Name: "Transfer", Data: map[string]casper.CLValue{
+			"owner":     ownerValue,
+			"spender":   {Type: &cltype.Option{Inner: cltype.Key}, Option: &clvalue.Option{}},
+			"recipient": recipientValue,
+			"token_id":  clvalue.NewCLString("7"),
+		}}, TokenIDIndex)
+		require.NoError(t, err)
+		assert.Nil(t, transfer.Spender)
+	})
+
+	t.Run("Empty events", func(t *testing.T) {
+		result, err := ParseEvent(ces.Event{Name: "VariablesSet"}, TokenIDIndex)
+		require.NoError(t, err)
+		assert.Equal(t, VariablesSet{}, result)
+	})
+
+	t.Run("Schema mismatch", func(t *testing.T) {
+		_, err := ParseMint(ces.Event{Name: "Mint", Data: map[string]casper.CLValue{
+			"recipient": clvalue.NewCLString("not a key"),
+			"token_id":  clvalue.NewCLString("1"),
+			"data":      clvalue.NewCLString(""),
+		}}, TokenIDIndex)
+		assert.ErrorIs(t, err, ErrUnexpectedEventSchema)
+
+		_, err = ParseApprovalForAll(ces.Event{Name: "ApprovalForAll", Data: map[string]casper.CLValue{
+			"owner":     ownerValue,
+			"operator":  recipientValue,
+			"token_ids": clvalue.NewCLString("1"),
+		}})
+		assert.ErrorIs(t, err, ErrUnexpectedEventSchema)
+
+		_, err = ParseMetadataUpdated(ces.Event{Name: "MetadataUpdated", Data: map[string]casper.CLValue{
+			"token_id": clvalue.NewCLString("not-a-token"),
+			"data":     clvalue.NewCLString(""),
+		}}, TokenIDHash)
+		assert.ErrorIs(t, err, ErrInvalidTokenID)
+
+		_, err = ParseEvent(ces.Event{Name: "Transfer", Data: map[string]casper.CLValue{}}, TokenIDIndex)
+		assert.ErrorIs(t, err, ErrUnexpectedEventSchema)
+
+		_, err = ParseEvent(ces.Event{Name: "Unknown"}, TokenIDIndex)
+		assert.ErrorIs(t, err, ErrUnexpectedEventName)
+	})
+}
+
+func TestParseTokenID(t *testing.T) {
+	kind, err := NewTokenIDKind(1)
+	require.NoError(t, err)
+	assert.Equal(t, TokenIDHash, kind)
+
+	_, err = NewTokenIDKind(2)
+	assert.ErrorIs(t, err, ErrUnknownIdentifierMode)
+
+	// the hash consisting of digits only is not taken for the ordinal index in the hash mode
+	tokenID, err := ParseTokenID("1234", TokenIDHash)
+	require.NoError(t, err)
+	assert.Equal(t, TokenID{Kind: TokenIDHash, Hash: "1234"}, tokenID)
+
+	tokenID, err = ParseTokenID("1234", TokenIDIndex)
+	require.NoError(t, err)
+	assert.Equal(t, NewTokenIndex(1234), tokenID)
+
+	_, err = ParseTokenID(tokenHash, TokenIDIndex)
+	assert.ErrorIs(t, err, ErrInvalidTokenID)
+
+	_, err = ParseTokenID("1", 0)
+	assert.ErrorIs(t, err, ErrUnknownIdentifierMode)
+}
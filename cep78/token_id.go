@@ -0,0 +1,101 @@
+package cep78
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/make-software/ces-go-parser/v2/internal/eventreader"
+)
+
+var (
+	ErrInvalidTokenID        = errors.New("error: invalid token id")
+	ErrUnknownIdentifierMode = errors.New("error: unknown CEP-78 identifier mode")
+)
+
+// TokenIDKind reflects the NFT identifier mode of the contract
+type TokenIDKind int
+
+const (
+	// TokenIDIndex is the ordinal token identifier
+	TokenIDIndex TokenIDKind = iota + 1
+	// TokenIDHash is the hex encoded token hash identifier
+	TokenIDHash
+)
+
+// NewTokenIDKind convert identifier_mode named key value of the contract (0 - Ordinal, 1 - Hash) to TokenIDKind
+func NewTokenIDKind(identifierMode uint8) (TokenIDKind, error) {
+	switch identifierMode {
+	case 0:
+		return TokenIDIndex, nil
+	case 1:
+		return TokenIDHash, nil
+	default:
+		return 0, fmt.Errorf("%w: %d", ErrUnknownIdentifierMode, identifierMode)
+	}
+}
+
+// TokenID is the normalised token identifier. CES events carry the identifier as String in both identifier modes,
+// the ordinal index is written as the decimal number and the hash as the hex string.
+type TokenID struct {
+	Kind  TokenIDKind
+	Index uint64
+	// Hash is lower-cased hex, empty for TokenIDIndex
+	Hash string
+}
+
+func NewTokenIndex(index uint64) TokenID {
+	return TokenID{Kind: TokenIDIndex, Index: index}
+}
+
+func NewTokenHash(hash string) (TokenID, error) {
+	hash = strings.ToLower(hash)
+	if _, err := hex.DecodeString(hash); err != nil || hash == "" {
+		return TokenID{}, ErrInvalidTokenID
+	}
+
+	return TokenID{Kind: TokenIDHash, Hash: hash}, nil
+}
+
+// ParseTokenID normalise token identifier from the event according to the identifier mode of the contract.
+// The mode is fixed per contract and can not be told from the value, e.g. a token hash may consist of digits only.
+func ParseTokenID(raw string, kind TokenIDKind) (TokenID, error) {
+	switch kind {
+	case TokenIDIndex:
+		index, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return TokenID{}, ErrInvalidTokenID
+		}
+		return NewTokenIndex(index), nil
+	case TokenIDHash:
+		return NewTokenHash(raw)
+	default:
+		return TokenID{}, fmt.Errorf("%w: %d", ErrUnknownIdentifierMode, kind)
+	}
+}
+
+func (t TokenID) IsHash() bool {
+	return t.Kind == TokenIDHash
+}
+
+func (t TokenID) String() string {
+	if t.Kind == TokenIDHash {
+		return t.Hash
+	}
+	return strconv.FormatUint(t.Index, 10)
+}
+
+func readTokenID(reader *eventreader.Reader, name string, kind TokenIDKind) TokenID {
+	raw := reader.String(name)
+	if reader.Err() != nil {
+		return TokenID{}
+	}
+
+	tokenID, err := ParseTokenID(raw, kind)
+	if err != nil {
+		reader.Fail(fmt.Errorf("%s: %w", name, err))
+	}
+	return tokenID
+}
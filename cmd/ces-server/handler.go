@@ -0,0 +1,266 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/rpc"
+
+	"github.com/make-software/ces-go-parser/v2"
+)
+
+const (
+	maxRequestBodySize = 16 << 20
+	// maxDecodeRequestBodySize fits hex encoded event and schemas of the default decode limits
+	maxDecodeRequestBodySize = 4<<20 + 1<<10
+)
+
+var errMethodNotAllowed = errors.New("method not allowed")
+
+type (
+	eventParser interface {
+		ParseExecutionResults(executionResult casper.ExecutionResult) ([]ces.ParseResult, error)
+		FetchContractSchemasBytes(contractHash casper.Hash) ([]byte, error)
+	}
+
+	handler struct {
+		parser eventParser
+	}
+
+	decodeEventRequest struct {
+		// Event is the hex encoded __events dictionary value
+		Event string `json:"event"`
+		// Schemas is the hex encoded __events_schema value
+		Schemas string `json:"schemas"`
+	}
+
+	valueResponse struct {
+		Type  string `json:"type"`
+		Bytes string `json:"bytes"`
+		Value string `json:"value"`
+	}
+
+	eventResponse struct {
+		ContractHash        casper.Hash              `json:"contract_hash"`
+		ContractPackageHash casper.Hash              `json:"contract_package_hash"`
+		RawData             string                   `json:"raw_data"`
+		Name                string                   `json:"name"`
+		TransformID         uint                     `json:"transform_id"`
+		EventID             uint                     `json:"event_id"`
+		Data                map[string]valueResponse `json:"data"`
+		Error               string                   `json:"error,omitempty"`
+	}
+
+	fieldResponse struct {
+		Name  string `json:"name"`
+		Type  string `json:"type"`
+		Bytes string `json:"bytes"`
+	}
+
+	schemaResponse struct {
+		Name   string          `json:"name"`
+		Fields []fieldResponse `json:"fields"`
+	}
+
+	errorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+func newHandler(parser eventParser) http.Handler {
+	h := &handler{parser: parser}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/execution-results/parse", h.parseExecutionResult)
+	mux.HandleFunc("/v1/deploys/parse", h.parseDeploy)
+	mux.HandleFunc("/v1/events/decode", h.decodeEvent)
+	mux.HandleFunc("/v1/contracts/schemas", h.contractSchemas)
+	return mux
+}
+
+// parseExecutionResult parse events out of the execution result JSON
+func (h *handler) parseExecutionResult(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errMethodNotAllowed)
+		return
+	}
+
+	var executionResult casper.ExecutionResult
+	if !readJSON(w, r, maxRequestBodySize, &executionResult) {
+		return
+	}
+
+	h.writeParseResults(w, executionResult)
+}
+
+// parseDeploy parse events out of the deploy JSON in the info_get_deploy result format
+func (h *handler) parseDeploy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errMethodNotAllowed)
+		return
+	}
+
+	var deployResult rpc.InfoGetDeployResult
+	if !readJSON(w, r, maxRequestBodySize, &deployResult) {
+		return
+	}
+
+	h.writeParseResults(w, deployResult.ExecutionResults.ExecutionResult)
+}
+
+func (h *handler) writeParseResults(w http.ResponseWriter, executionResult casper.ExecutionResult) {
+	results, err := h.parser.ParseExecutionResults(executionResult)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	response := make([]eventResponse, 0, len(results))
+	for _, result := range results {
+		one := newEventResponse(result.Event)
+		if result.Error != nil {
+			one.Error = result.Error.Error()
+		}
+		response = append(response, one)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// decodeEvent decode a single event against the provided schema
+func (h *handler) decodeEvent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errMethodNotAllowed)
+		return
+	}
+
+	var request decodeEventRequest
+	if !readJSON(w, r, maxDecodeRequestBodySize, &request) {
+		return
+	}
+
+	schemasBytes, err := hex.DecodeString(request.Schemas)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	schemas, err := ces.NewSchemasFromBytes(schemasBytes)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	eventName, eventData, err := ces.ParseEventNameAndData(request.Event, schemas)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newEventResponse(ces.Event{
+		Name: eventName,
+		Data: eventData,
+	}))
+}
+
+// contractSchemas return event schemas stored by the contract
+func (h *handler) contractSchemas(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errMethodNotAllowed)
+		return
+	}
+
+	contractHash, err := casper.NewHash(strings.TrimPrefix(r.URL.Query().Get("contract_hash"), "hash-"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	schemasBytes, err := h.parser.FetchContractSchemasBytes(contractHash)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	schemas, err := ces.NewSchemasFromBytes(schemasBytes)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	response := make([]schemaResponse, 0, len(schemas))
+	for name, fields := range schemas {
+		one := schemaResponse{
+			Name:   name,
+			Fields: make([]fieldResponse, 0, len(fields)),
+		}
+		for _, field := range fields {
+			one.Fields = append(one.Fields, fieldResponse{
+				Name:  field.ParamName,
+				Type:  field.ParamType.String(),
+				Bytes: hex.EncodeToString(field.ParamType.Bytes()),
+			})
+		}
+		response = append(response, one)
+	}
+
+	sort.Slice(response, func(i, j int) bool {
+		return response[i].Name < response[j].Name
+	})
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func newEventResponse(event ces.Event) eventResponse {
+	response := eventResponse{
+		ContractHash:        event.ContractHash,
+		ContractPackageHash: event.ContractPackageHash,
+		RawData:             event.RawData,
+		Name:                event.Name,
+		TransformID:         event.TransformID,
+		EventID:             event.EventID,
+		Data:                make(map[string]valueResponse, len(event.Data)),
+	}
+
+	for name, value := range event.Data {
+		response.Data[name] = valueResponse{
+			Type:  value.Type.String(),
+			Bytes: hex.EncodeToString(value.Bytes()),
+			Value: value.String(),
+		}
+	}
+
+	return response
+}
+
+// readJSON decode the request body limited by maxSize into the value, the error response is written on failure
+func readJSON(w http.ResponseWriter, r *http.Request, maxSize int64, value interface{}) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSize)).Decode(value)
+	if err == nil {
+		return true
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+		return false
+	}
+
+	writeError(w, http.StatusBadRequest, err)
+	return false
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
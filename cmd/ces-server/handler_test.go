@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2"
+)
+
+var (
+	votingSchemaHex    = readHexFixture("../../utils/fixtures/events/voting_schema.hex")
+	ballotCastEventHex = readHexFixture("../../utils/fixtures/events/ballot_cast.hex")
+)
+
+func readHexFixture(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+type stubParser struct {
+	results []ces.ParseResult
+}
+
+func (p stubParser) ParseExecutionResults(casper.ExecutionResult) ([]ces.ParseResult, error) {
+	return p.results, nil
+}
+
+func (p stubParser) FetchContractSchemasBytes(casper.Hash) ([]byte, error) {
+	return nil, nil
+}
+
+func TestDecodeEvent(t *testing.T) {
+	server := httptest.NewServer(newHandler(stubParser{}))
+	defer server.Close()
+
+	body := `{"event": "` + ballotCastEventHex + `", "schemas": "` + votingSchemaHex + `"}`
+	resp, err := http.Post(server.URL+"/v1/events/decode", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var event eventResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&event))
+	assert.Equal(t, "BallotCast", event.Name)
+	assert.Equal(t, "1000", event.Data["stake"].Value)
+	assert.Equal(t, "U512", event.Data["stake"].Type)
+
+	resp, err = http.Get(server.URL + "/v1/events/decode")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	body = `{"event": "` + strings.Repeat("00", maxDecodeRequestBodySize) + `"}`
+	resp, err = http.Post(server.URL+"/v1/events/decode", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
+
+func TestParseExecutionResult(t *testing.T) {
+	server := httptest.NewServer(newHandler(stubParser{
+		results: []ces.ParseResult{{Event: ces.Event{Name: "Transfer", EventID: 7}}, {Error: ces.ErrEventNameNotInSchema, Event: ces.Event{Name: "Unknown"}}},
+	}))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/v1/execution-results/parse", "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var events []eventResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&events))
+	require.Len(t, events, 2)
+	assert.Equal(t, "Transfer", events[0].Name)
+	assert.Equal(t, uint(7), events[0].EventID)
+	assert.Empty(t, events[0].Error)
+	assert.Equal(t, ces.ErrEventNameNotInSchema.Error(), events[1].Error)
+}
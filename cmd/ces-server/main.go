@@ -0,0 +1,55 @@
+// Command ces-server exposes CES events parsing over a small REST API,
+// so that services written in other languages could reuse the parser.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+
+	"github.com/make-software/ces-go-parser/v2"
+)
+
+func main() {
+	var (
+		addr      = flag.String("addr", ":8080", "HTTP listen address")
+		nodeURL   = flag.String("node", "http://localhost:11101/rpc", "Casper node RPC address")
+		contracts = flag.String("contracts", "", "Comma separated list of the observed contract hashes")
+	)
+	flag.Parse()
+
+	rpcClient := casper.NewRPCClient(casper.NewRPCHandler(*nodeURL, http.DefaultClient))
+
+	var contractHashes []casper.Hash
+	for _, one := range strings.Split(*contracts, ",") {
+		if one = strings.TrimSpace(one); one == "" {
+			continue
+		}
+		hash, err := casper.NewHash(strings.TrimPrefix(one, "hash-"))
+		if err != nil {
+			log.Fatalf("invalid contract hash %q: %s", one, err)
+		}
+		contractHashes = append(contractHashes, hash)
+	}
+
+	// execution results come from the clients, they must not rewrite the schemas shared by all requests
+	parser, err := ces.NewParser(rpcClient, contractHashes, ces.WithoutSchemaRefresh())
+	if err != nil {
+		log.Fatalf("failed to create parser: %s", err)
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newHandler(parser),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("listening on %s, observing %d contracts", *addr, len(contractHashes))
+	if err = server.ListenAndServe(); err != nil {
+		log.Fatal(err)
+	}
+}
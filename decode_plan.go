@@ -0,0 +1,157 @@
+package ces
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue"
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+)
+
+var ErrEventPayloadTooShort = errors.New("error: event payload is shorter than its schema")
+
+// eventDecodePlan is the event schema compiled for decoding. It is calculated once per contract schemas,
+// see ContractMetadata.SetSchemas.
+type eventDecodePlan struct {
+	fields []SchemaData
+	// fingerprint is the EventSignatureFingerprint of the fields, the plan is used for the event of the same fingerprint only
+	fingerprint SchemaFingerprint
+	// minSize is the lower bound of the encoded event data size, shorter payloads are rejected before decoding
+	minSize int
+	// shape is the maximal CLType nesting and ByteArray size of the fields checked against DecodeLimits
+	shape clTypeShape
+	// prefixed marks fields with length prefixed values, they are checked against DecodeLimits before decoding
+	prefixed []bool
+}
+
+func compileDecodePlans(schemas Schemas, fingerprints map[EventName]SchemaFingerprint) map[EventName]eventDecodePlan {
+	plans := make(map[EventName]eventDecodePlan, len(schemas))
+	for name, fields := range schemas {
+		plan := compileDecodePlan(fields)
+		plan.fingerprint = fingerprints[name]
+		plans[name] = plan
+	}
+	return plans
+}
+
+func compileDecodePlan(fields []SchemaData) eventDecodePlan {
+	plan := eventDecodePlan{fields: fields, prefixed: make([]bool, len(fields))}
+	for i, field := range fields {
+		plan.minSize += minEncodedSize(field.ParamType)
+
+		shape := newCLTypeShape(field.ParamType)
+		plan.shape.depth = max(plan.shape.depth, shape.depth)
+		plan.shape.byteArraySize = max(plan.shape.byteArraySize, shape.byteArraySize)
+		plan.prefixed[i] = shape.prefixed
+	}
+	return plan
+}
+
+// decode event data from the payload following the event name, offset is the payload position in the CES event payload.
+// The rest of the payload following the event data is returned without copying.
+func (p eventDecodePlan) decode(payload []byte, offset int, limits DecodeLimits) (map[string]casper.CLValue, []byte, error) {
+	if err := limits.checkShape(p.shape); err != nil {
+		return nil, nil, &ParseError{Offset: offset, Err: err}
+	}
+	if len(payload) < p.minSize {
+		return nil, nil, &ParseError{Offset: offset, Err: ErrEventPayloadTooShort}
+	}
+
+	buf := bytes.NewBuffer(payload)
+	result := make(map[string]casper.CLValue, len(p.fields))
+	for i, field := range p.fields {
+		fieldOffset := offset + len(payload) - buf.Len()
+		if p.prefixed[i] {
+			if _, err := limits.checkValue(buf.Bytes(), field.ParamType); err != nil {
+				return nil, nil, &ParseError{FieldName: field.ParamName, FieldType: field.ParamType, Offset: fieldOffset, Err: err}
+			}
+		}
+		value, err := clvalue.FromBufferByType(buf, field.ParamType)
+		if err != nil {
+			return nil, nil, &ParseError{FieldName: field.ParamName, FieldType: field.ParamType, Offset: fieldOffset, Err: err}
+		}
+		result[field.ParamName] = value
+	}
+	return result, buf.Bytes(), nil
+}
+
+// minEncodedSize return the minimal number of bytes the value of CLType takes
+func minEncodedSize(clType cltype.CLType) int {
+	switch one := clType.(type) {
+	case nil:
+		return 0
+	case *cltype.ByteArray:
+		return int(one.Size)
+	case *cltype.Result:
+		return 1 + min(minEncodedSize(one.InnerOk), minEncodedSize(one.InnerErr))
+	case *cltype.Tuple1:
+		return minEncodedSize(one.Inner1)
+	case *cltype.Tuple2:
+		return minEncodedSize(one.Inner1) + minEncodedSize(one.Inner2)
+	case *cltype.Tuple3:
+		return minEncodedSize(one.Inner1) + minEncodedSize(one.Inner2) + minEncodedSize(one.Inner3)
+	}
+
+	switch clType.GetTypeID() {
+	case cltype.TypeIDBool, cltype.TypeIDU8, cltype.TypeIDOption:
+		return 1
+	// big integers are prefixed with the byte length, zero takes the prefix only
+	case cltype.TypeIDU128, cltype.TypeIDU256, cltype.TypeIDU512:
+		return 1
+	case cltype.TypeIDI32, cltype.TypeIDU32, cltype.TypeIDString, cltype.TypeIDList, cltype.TypeIDMap:
+		return 4
+	case cltype.TypeIDI64, cltype.TypeIDU64:
+		return 8
+	case cltype.TypeIDURef:
+		return 33
+	// Key and PublicKey start with the tag byte, the rest depends on the variant
+	case cltype.TypeIDKey, cltype.TypeIDPublicKey:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// clTypeShape describes the CLType properties limited by DecodeLimits
+type clTypeShape struct {
+	depth         int
+	byteArraySize int
+	// prefixed is true if values of the CLType contain String, List or Map length prefixes
+	prefixed bool
+}
+
+func newCLTypeShape(clType cltype.CLType) clTypeShape {
+	var inner []cltype.CLType
+	shape := clTypeShape{depth: 1}
+	switch one := clType.(type) {
+	case nil:
+		return clTypeShape{}
+	case *cltype.Option:
+		inner = []cltype.CLType{one.Inner}
+	case *cltype.List:
+		inner, shape.prefixed = []cltype.CLType{one.ElementsType}, true
+	case *cltype.Map:
+		inner, shape.prefixed = []cltype.CLType{one.Key, one.Val}, true
+	case *cltype.ByteArray:
+		shape.byteArraySize = int(one.Size)
+	case *cltype.Result:
+		inner = []cltype.CLType{one.InnerOk, one.InnerErr}
+	case *cltype.Tuple1:
+		inner = []cltype.CLType{one.Inner1}
+	case *cltype.Tuple2:
+		inner = []cltype.CLType{one.Inner1, one.Inner2}
+	case *cltype.Tuple3:
+		inner = []cltype.CLType{one.Inner1, one.Inner2, one.Inner3}
+	default:
+		shape.prefixed = clType.GetTypeID() == cltype.TypeIDString
+	}
+
+	for _, one := range inner {
+		innerShape := newCLTypeShape(one)
+		shape.depth = max(shape.depth, innerShape.depth+1)
+		shape.byteArraySize = max(shape.byteArraySize, innerShape.byteArraySize)
+		shape.prefixed = shape.prefixed || innerShape.prefixed
+	}
+	return shape
+}
@@ -0,0 +1,70 @@
+package ces
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodePlan(t *testing.T) {
+	metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	metadata.SetSchemas(metadata.Schemas)
+
+	rawEvent, err := hex.DecodeString(ballotCastEventHex)
+	require.NoError(t, err)
+
+	eventMetadata, err := parseEventMetadataFromDictionaryBytes(rawEvent, DefaultDecodeLimits)
+	require.NoError(t, err)
+	assert.Equal(t, "BallotCast", eventMetadata.Name)
+	assert.Equal(t, uint(2), eventMetadata.EventID)
+	assert.Equal(t, "uref-d2263e86f497f42e405d5d1390aa3c1a8bfc35f3699fdc3be806a5cfe139dac9-007", eventMetadata.Uref.String())
+
+	result := parseEventWithMetadata(metadata, eventMetadata, DefaultDecodeLimits)
+	require.NoError(t, result.Error)
+	assert.Len(t, result.Event.Data, 5)
+
+	// raw bytes are the dictionary payload including the event name, the payload has no bytes after the event data
+	assert.Equal(t, ballotCastEventHex[16:16+2*0x3e], hex.EncodeToString(result.Event.RawBytes()))
+	assert.Empty(t, result.Event.RawData)
+
+	t.Run("JSON", func(t *testing.T) {
+		encoded, err := json.Marshal(result.Event)
+		require.NoError(t, err)
+		assert.Contains(t, string(encoded), `"raw_data":""`)
+
+		var decoded Event
+		require.NoError(t, json.Unmarshal(encoded, &decoded))
+		assert.Equal(t, result.Event.EventID, decoded.EventID)
+	})
+
+	t.Run("Stale plan", func(t *testing.T) {
+		changed := metadata
+		changed.Schemas = Schemas{"BallotCast": metadata.Schemas["BallotCast"][:1]}
+		changed.EventFingerprints = changed.Schemas.EventFingerprints()
+
+		// the plan compiled for the old fields is not used for the changed schema
+		result := parseEventWithMetadata(changed, eventMetadata, DefaultDecodeLimits)
+		require.NoError(t, result.Error)
+		assert.Len(t, result.Event.Data, 1)
+		assert.NotEmpty(t, result.Event.RawData)
+	})
+
+	t.Run("Truncated payload", func(t *testing.T) {
+		eventMetadata, err := parseEventMetadataFromDictionaryBytes(rawEvent, DefaultDecodeLimits)
+		require.NoError(t, err)
+		eventMetadata.Payload.Truncate(3)
+
+		result := parseEventWithMetadata(metadata, eventMetadata, DefaultDecodeLimits)
+		assert.ErrorIs(t, result.Error, ErrEventPayloadTooShort)
+	})
+
+	t.Run("Invalid dictionary", func(t *testing.T) {
+		for _, cut := range []int{3, 20, 80, len(rawEvent) - 1} {
+			_, err := newDictionary(rawEvent[:cut], DefaultDecodeLimits)
+			assert.Error(t, err)
+		}
+	})
+}
@@ -1,66 +1,85 @@
 package ces
 
 import (
-	"bytes"
+	"encoding/binary"
 	"errors"
 
 	"github.com/make-software/casper-go-sdk/v2/casper"
-	"github.com/make-software/casper-go-sdk/v2/types/clvalue"
 	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
 	"github.com/make-software/casper-go-sdk/v2/types/key"
 )
 
+var errInvalidDictionary = errors.New("can't parse dictionary event")
+
+const dictionaryURefSize = 32
+
 // Dictionary value has always three parts. Data, dictionary URef and dictionary item key
 // Each of the three parts has the length as prefix.
 // The data for CES is stored as a CL Value. Always a List(u8). It starts with the length of the data and ends with 0e03.
 type dictionary struct {
-	Data clvalue.List
+	// Data is the CES event payload, it is sliced from the source bytes without copying
+	Data []byte
 	Uref casper.Uref
 	Key  string
 }
 
-func newDictionary(source []byte) (dictionary, error) {
-	buf := bytes.NewBuffer(source)
-	data, err := clvalue.FromBuffer(buf)
+func newDictionary(source []byte, limits DecodeLimits) (dictionary, error) {
+	if err := checkLimit(LimitPayloadSize, len(source), limits.MaxPayloadSize); err != nil {
+		return dictionary{}, err
+	}
+
+	value, rest, err := readLengthPrefixed(source)
 	if err != nil {
 		return dictionary{}, err
 	}
 
-	if data.List == nil || data.List.Type.ElementsType != cltype.UInt8 {
-		return dictionary{}, errors.New("can't parse dictionary event")
+	// List(u8) value is the number of elements followed by the bytes
+	data, remainder, err := readLengthPrefixed(value)
+	if err != nil || len(remainder) != 0 {
+		return dictionary{}, errInvalidDictionary
 	}
 
-	_, err = clvalue.TrimByteSize(buf)
-	if err != nil {
-		return dictionary{}, err
+	if len(rest) < 2 || rest[0] != byte(cltype.TypeIDList) || rest[1] != byte(cltype.TypeIDU8) {
+		return dictionary{}, errInvalidDictionary
 	}
+	rest = rest[2:]
 
-	urefBytes, err := clvalue.FromBufferByType(buf, cltype.NewByteArray(32))
-	if err != nil {
-		return dictionary{}, err
+	// the byte size of the URef bytes is not needed
+	if len(rest) < 4+dictionaryURefSize {
+		return dictionary{}, errInvalidDictionary
 	}
+	rest = rest[4:]
 
-	uref, err := key.NewURefFromBytes(append(urefBytes.Bytes(), key.UrefAccessReadAddWrite))
+	urefBytes := make([]byte, 0, dictionaryURefSize+1)
+	urefBytes = append(append(urefBytes, rest[:dictionaryURefSize]...), key.UrefAccessReadAddWrite)
+	uref, err := key.NewURefFromBytes(urefBytes)
 	if err != nil {
 		return dictionary{}, err
 	}
 
-	dictKey, err := clvalue.FromBufferByType(buf, cltype.String)
+	dictKey, _, err := readLengthPrefixed(rest[dictionaryURefSize:])
 	if err != nil {
 		return dictionary{}, err
 	}
 
 	return dictionary{
-		Data: *data.List,
+		Data: data,
 		Uref: uref,
-		Key:  dictKey.String(),
+		Key:  string(dictKey),
 	}, nil
 }
 
-func (d dictionary) DataToBytes() []byte {
-	var result []byte
-	for _, one := range d.Data.Elements {
-		result = append(result, one.UI8.Value())
+// readLengthPrefixed split the u32 length prefixed bytes from the rest of the source
+func readLengthPrefixed(source []byte) ([]byte, []byte, error) {
+	if len(source) < 4 {
+		return nil, nil, errInvalidDictionary
 	}
-	return result
+
+	length := binary.LittleEndian.Uint32(source)
+	source = source[4:]
+	if uint64(length) > uint64(len(source)) {
+		return nil, nil, errInvalidDictionary
+	}
+
+	return source[:length:length], source[length:], nil
 }
@@ -3,11 +3,9 @@ package ces
 import (
 	"bytes"
 	"encoding/hex"
-	"strings"
 
 	"github.com/make-software/casper-go-sdk/v2/casper"
 	"github.com/make-software/casper-go-sdk/v2/types/clvalue"
-	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
 )
 
 type ParseResult struct {
@@ -23,6 +21,13 @@ type Event struct {
 	Name                string                    `json:"name"`
 	TransformID         uint                      `json:"transform_id"`
 	EventID             uint                      `json:"event_id"`
+	// rawPayload is the CES event payload sliced from the source bytes, it is not copied
+	rawPayload []byte
+}
+
+// RawBytes return CES event payload including the event name, the slice must not be modified
+func (e Event) RawBytes() []byte {
+	return e.rawPayload
 }
 
 // ParseEventNameAndData parse provided rawEvent according to event schema, return EventName and EventData
@@ -32,44 +37,64 @@ func ParseEventNameAndData(eventHex string, schemas Schemas) (EventName, map[str
 		return "", nil, err
 	}
 
-	dictionary, err := newDictionary(decoded)
+	dictionary, err := newDictionary(decoded, DefaultDecodeLimits)
 	if err != nil {
 		return "", nil, err
 	}
-	payload := bytes.NewBuffer(dictionary.DataToBytes())
-	eventNameWithPrefix, err := clvalue.FromBufferByType(payload, cltype.String)
+
+	eventNameWithPrefix, payload, err := readLengthPrefixed(dictionary.Data)
 	if err != nil {
-		return "", nil, err
+		return "", nil, &ParseError{Err: err}
 	}
 
-	if !strings.HasPrefix(eventNameWithPrefix.String(), eventPrefix) {
-		return "", nil, ErrNoEventPrefixInEvent
+	if !bytes.HasPrefix(eventNameWithPrefix, []byte(eventPrefix)) {
+		return "", nil, &ParseError{EventName: string(eventNameWithPrefix), Err: ErrNoEventPrefixInEvent}
 	}
 
-	eventName := strings.TrimPrefix(eventNameWithPrefix.String(), eventPrefix)
+	eventName := string(eventNameWithPrefix[len(eventPrefix):])
 	schema, ok := schemas[eventName]
 	if !ok {
-		return "", nil, ErrEventNameNotInSchema
+		return "", nil, &ParseError{EventName: eventName, Err: ErrEventNameNotInSchema}
 	}
 
-	eventData, err := ParseEventDataFromSchemaBytes(schema, payload)
+	eventData, _, err := compileDecodePlan(schema).decode(payload, len(dictionary.Data)-len(payload), DefaultDecodeLimits)
 	if err != nil {
-		return "", nil, err
+		parseErr := asParseError(err)
+		parseErr.EventName = eventName
+		return "", nil, parseErr
 	}
 
 	return eventName, eventData, nil
 }
 
+// ParseEventDataFromSchemaBytes decode event data fields from buf, failed field is reported with *ParseError
+// with Offset relative to the buf position at the call. Fields are checked against DefaultDecodeLimits.
 func ParseEventDataFromSchemaBytes(schemas []SchemaData, buf *bytes.Buffer) (map[EventName]casper.CLValue, error) {
+	if err := checkLimit(LimitPayloadSize, buf.Len(), DefaultDecodeLimits.MaxPayloadSize); err != nil {
+		return nil, &ParseError{Err: err}
+	}
+
+	plan := compileDecodePlan(schemas)
+	if err := DefaultDecodeLimits.checkShape(plan.shape); err != nil {
+		return nil, &ParseError{Err: err}
+	}
+
 	result := make(map[EventName]casper.CLValue, len(schemas))
 	var (
-		one casper.CLValue
-		err error
+		one   casper.CLValue
+		err   error
+		start = buf.Len()
 	)
-	for _, item := range schemas {
+	for i, item := range schemas {
+		offset := start - buf.Len()
+		if plan.prefixed[i] {
+			if _, err = DefaultDecodeLimits.checkValue(buf.Bytes(), item.ParamType); err != nil {
+				return nil, &ParseError{FieldName: item.ParamName, FieldType: item.ParamType, Offset: offset, Err: err}
+			}
+		}
 		one, err = clvalue.FromBufferByType(buf, item.ParamType)
 		if err != nil {
-			return nil, err
+			return nil, &ParseError{FieldName: item.ParamName, FieldType: item.ParamType, Offset: offset, Err: err}
 		}
 		result[item.ParamName] = one
 	}
@@ -0,0 +1,52 @@
+package ces
+
+import (
+	"github.com/make-software/casper-go-sdk/v2/casper"
+)
+
+// EventFilter describes which events EventParser should decode.
+// Each non-empty field restricts the result set, empty fields match any value.
+type EventFilter struct {
+	ContractHashes        []casper.Hash
+	ContractPackageHashes []casper.Hash
+	EventNames            []EventName
+}
+
+// FilteredEventHandler is called with the contract hash and the id of every event dropped by EventFilter,
+// see WithFilteredEventHandler
+type FilteredEventHandler func(contractHash casper.Hash, eventID uint)
+
+// Match checks whether event with provided name emitted by the contract passes the filter
+func (f *EventFilter) Match(contractMetadata ContractMetadata, eventName EventName) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.ContractHashes) > 0 && !containsHash(f.ContractHashes, contractMetadata.ContractHash) {
+		return false
+	}
+
+	if len(f.ContractPackageHashes) > 0 && !containsHash(f.ContractPackageHashes, contractMetadata.ContractPackageHash) {
+		return false
+	}
+
+	if len(f.EventNames) > 0 {
+		for _, name := range f.EventNames {
+			if name == eventName {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+func containsHash(hashes []casper.Hash, hash casper.Hash) bool {
+	for _, one := range hashes {
+		if one == hash {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package ces
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+)
+
+// SchemaFingerprint is the deterministic SHA-256 content hash of schemas or a single event signature
+type SchemaFingerprint [sha256.Size]byte
+
+func (f SchemaFingerprint) String() string {
+	return hex.EncodeToString(f[:])
+}
+
+func (f SchemaFingerprint) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+func (f *SchemaFingerprint) UnmarshalText(text []byte) error {
+	decoded, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+
+	if len(decoded) != len(f) {
+		return ErrInvalidSchemaFormat
+	}
+
+	copy(f[:], decoded)
+	return nil
+}
+
+// EventSignatureFingerprint hash the event name with the ordered field names and CLType bytes.
+// Events of the same shape emitted by different contracts have the same fingerprint.
+func EventSignatureFingerprint(name EventName, fields []SchemaData) SchemaFingerprint {
+	hasher := sha256.New()
+	writeEventSignature(hasher, name, fields)
+
+	var fingerprint SchemaFingerprint
+	copy(fingerprint[:], hasher.Sum(nil))
+	return fingerprint
+}
+
+// Fingerprint hash all event signatures ordered by the event name
+func (t Schemas) Fingerprint() SchemaFingerprint {
+	hasher := sha256.New()
+	writeLength(hasher, len(t))
+	for _, name := range sortedEventNames(t) {
+		writeEventSignature(hasher, name, t[name])
+	}
+
+	var fingerprint SchemaFingerprint
+	copy(fingerprint[:], hasher.Sum(nil))
+	return fingerprint
+}
+
+// EventFingerprints return signature fingerprint of every event
+func (t Schemas) EventFingerprints() map[EventName]SchemaFingerprint {
+	fingerprints := make(map[EventName]SchemaFingerprint, len(t))
+	for name, fields := range t {
+		fingerprints[name] = EventSignatureFingerprint(name, fields)
+	}
+	return fingerprints
+}
+
+// writeEventSignature write length prefixed event name, field names and CLType bytes
+func writeEventSignature(hasher hash.Hash, name EventName, fields []SchemaData) {
+	writeBytes(hasher, []byte(name))
+	writeLength(hasher, len(fields))
+	for _, field := range fields {
+		writeBytes(hasher, []byte(field.ParamName))
+		writeBytes(hasher, field.ParamType.Bytes())
+	}
+}
+
+func writeBytes(hasher hash.Hash, data []byte) {
+	writeLength(hasher, len(data))
+	hasher.Write(data)
+}
+
+func writeLength(hasher hash.Hash, length int) {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], uint32(length))
+	hasher.Write(buf[:])
+}
@@ -0,0 +1,44 @@
+package ces
+
+import (
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaFingerprints(t *testing.T) {
+	transfer := []SchemaData{
+		{ParamName: "from", ParamType: cltype.Key},
+		{ParamName: "to", ParamType: cltype.Key},
+		{ParamName: "amount", ParamType: cltype.UInt256},
+	}
+
+	first := Schemas{
+		"Transfer": transfer,
+		"Burn":     {{ParamName: "owner", ParamType: cltype.Key}, {ParamName: "amount", ParamType: cltype.UInt256}},
+	}
+	second := Schemas{
+		"Transfer": {
+			{ParamName: "from", ParamType: cltype.Key},
+			{ParamName: "to", ParamType: cltype.Key},
+			{ParamName: "amount", ParamType: cltype.UInt256},
+		},
+	}
+
+	assert.Equal(t, first.EventFingerprints()["Transfer"], second.EventFingerprints()["Transfer"])
+	assert.Equal(t, EventSignatureFingerprint("Transfer", transfer), second.EventFingerprints()["Transfer"])
+	assert.NotEqual(t, first.Fingerprint(), second.Fingerprint())
+	assert.Equal(t, first.Fingerprint(), first.Fingerprint())
+
+	reordered := []SchemaData{transfer[1], transfer[0], transfer[2]}
+	assert.NotEqual(t, EventSignatureFingerprint("Transfer", transfer), EventSignatureFingerprint("Transfer", reordered))
+
+	retyped := []SchemaData{transfer[0], transfer[1], {ParamName: "amount", ParamType: cltype.UInt512}}
+	assert.NotEqual(t, EventSignatureFingerprint("Transfer", transfer), EventSignatureFingerprint("Transfer", retyped))
+
+	var metadata ContractMetadata
+	metadata.SetSchemas(first)
+	assert.Equal(t, first.Fingerprint(), metadata.SchemasFingerprint)
+	assert.Len(t, metadata.EventFingerprints, 2)
+}
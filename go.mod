@@ -5,6 +5,7 @@ go 1.21
 toolchain go1.22.0
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/golang/mock v1.6.0
 	github.com/make-software/casper-go-sdk/v2 v2.0.1-beta1.0.20240725075941-fdac8c4ae070
 	github.com/stretchr/testify v1.8.2
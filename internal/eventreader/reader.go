@@ -0,0 +1,119 @@
+// Package eventreader reads typed fields of CES event data for the token standard packages
+package eventreader
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+
+	"github.com/make-software/ces-go-parser/v2"
+)
+
+// Reader read typed fields from the event data, remember the first error and check that all fields are consumed
+type Reader struct {
+	event     ces.Event
+	schemaErr error
+	consumed  int
+	err       error
+}
+
+// New create Reader for the event, nameErr is returned if the event name is not expectedName,
+// schemaErr if event data does not match the expected fields
+func New(event ces.Event, expectedName string, nameErr, schemaErr error) *Reader {
+	reader := &Reader{event: event, schemaErr: schemaErr}
+	if event.Name != expectedName {
+		reader.err = fmt.Errorf("%w: %s, expected %s", nameErr, event.Name, expectedName)
+	}
+	return reader
+}
+
+func (r *Reader) Has(name string) bool {
+	_, ok := r.event.Data[name]
+	return ok
+}
+
+// Fail set the reader error if there is no error yet
+func (r *Reader) Fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *Reader) Err() error {
+	return r.err
+}
+
+func (r *Reader) Value(name string, expectedType cltype.CLType) (casper.CLValue, bool) {
+	if r.err != nil {
+		return casper.CLValue{}, false
+	}
+
+	value, ok := r.event.Data[name]
+	if !ok {
+		r.err = fmt.Errorf("%w: %s missing field %s", r.schemaErr, r.event.Name, name)
+		return casper.CLValue{}, false
+	}
+
+	if value.Type == nil || !bytes.Equal(value.Type.Bytes(), expectedType.Bytes()) {
+		r.err = fmt.Errorf("%w: %s.%s expected %s", r.schemaErr, r.event.Name, name, expectedType)
+		return casper.CLValue{}, false
+	}
+
+	r.consumed++
+	return value, true
+}
+
+func (r *Reader) Key(name string) casper.Key {
+	value, ok := r.Value(name, cltype.Key)
+	if !ok || value.Key == nil {
+		return casper.Key{}
+	}
+	return *value.Key
+}
+
+// OptionalKey read Option<Key> field, return nil for the absent field or None value
+func (r *Reader) OptionalKey(name string) *casper.Key {
+	if !r.Has(name) {
+		return nil
+	}
+
+	value, ok := r.Value(name, &cltype.Option{Inner: cltype.Key})
+	if !ok || value.Option == nil || value.Option.Inner == nil || value.Option.Inner.Key == nil {
+		return nil
+	}
+
+	result := *value.Option.Inner.Key
+	return &result
+}
+
+func (r *Reader) String(name string) string {
+	value, ok := r.Value(name, cltype.String)
+	if !ok || value.StringVal == nil {
+		return ""
+	}
+	return value.StringVal.String()
+}
+
+func (r *Reader) UInt256(name string) *big.Int {
+	value, ok := r.Value(name, cltype.UInt256)
+	if !ok || value.UI256 == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(value.UI256.Value())
+}
+
+// Finish return the first read error or schemaErr if the event has fields that were not read
+func (r *Reader) Finish() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if r.consumed != len(r.event.Data) {
+		return fmt.Errorf("%w: %s has unexpected fields", r.schemaErr, r.event.Name)
+	}
+
+	return nil
+}
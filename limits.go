@@ -0,0 +1,294 @@
+package ces
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue"
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+)
+
+var ErrDecodeLimitExceeded = errors.New("error: decode limit exceeded")
+
+const (
+	LimitPayloadSize      = "payload size"
+	LimitCollectionLength = "collection length"
+	LimitNestingDepth     = "nesting depth"
+	LimitSchemaSize       = "schema size"
+)
+
+type (
+	// DecodeLimits bound resources spent on decoding payloads of arbitrary contracts, zero value of a limit disables it
+	DecodeLimits struct {
+		// MaxPayloadSize limits the size of __events dictionary value
+		MaxPayloadSize int
+		// MaxCollectionLength limits the length prefix of String, List, Map and the size of ByteArray
+		MaxCollectionLength int
+		// MaxNestingDepth limits nesting of CLTypes in schemas
+		MaxNestingDepth int
+		// MaxSchemaSize limits the size of __events_schema value
+		MaxSchemaSize int
+	}
+
+	// DecodeLimitError reports the exceeded limit, it matches ErrDecodeLimitExceeded with errors.Is
+	DecodeLimitError struct {
+		Limit string
+		Value int
+		Max   int
+	}
+)
+
+// DefaultDecodeLimits are applied by package level decoding functions and by EventParser without WithDecodeLimits option.
+// MaxCollectionLength is not lower than MaxPayloadSize, so any String or List fitting the payload is decoded.
+var DefaultDecodeLimits = DecodeLimits{
+	MaxPayloadSize:      1 << 20,
+	MaxCollectionLength: 1 << 20,
+	MaxNestingDepth:     16,
+	MaxSchemaSize:       1 << 20,
+}
+
+func (e *DecodeLimitError) Error() string {
+	return fmt.Sprintf("%s: %s %d exceeds %d", ErrDecodeLimitExceeded, e.Limit, e.Value, e.Max)
+}
+
+func (e *DecodeLimitError) Unwrap() error {
+	return ErrDecodeLimitExceeded
+}
+
+func checkLimit(limit string, value, max int) error {
+	if max > 0 && value > max {
+		return &DecodeLimitError{Limit: limit, Value: value, Max: max}
+	}
+	return nil
+}
+
+// checkShape check CLType nesting and ByteArray size of the schema fields
+func (l DecodeLimits) checkShape(shape clTypeShape) error {
+	if err := checkLimit(LimitNestingDepth, shape.depth, l.MaxNestingDepth); err != nil {
+		return err
+	}
+	return checkLimit(LimitCollectionLength, shape.byteArraySize, l.MaxCollectionLength)
+}
+
+// checkValue walk the encoded value of CLType at the start of data checking length prefixes against the limits and
+// the available bytes, returns the size of the value. Values of Key and PublicKey have bounded size and are measured
+// with the SDK decoder.
+func (l DecodeLimits) checkValue(data []byte, clType cltype.CLType) (int, error) {
+	next := func(size int) (int, error) {
+		if size > len(data) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return size, nil
+	}
+
+	readLength := func() (int, error) {
+		if len(data) < 4 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		length := int(binary.LittleEndian.Uint32(data))
+		if err := checkLimit(LimitCollectionLength, length, l.MaxCollectionLength); err != nil {
+			return 0, err
+		}
+		return length, nil
+	}
+
+	// sequence check count values of elementTypes following the offset
+	sequence := func(offset, count int, elementTypes ...cltype.CLType) (int, error) {
+		minSize := 0
+		for _, one := range elementTypes {
+			minSize += minEncodedSize(one)
+		}
+		if count*minSize > len(data)-offset {
+			return 0, io.ErrUnexpectedEOF
+		}
+
+		for i := 0; i < count; i++ {
+			for _, one := range elementTypes {
+				size, err := l.checkValue(data[offset:], one)
+				if err != nil {
+					return 0, err
+				}
+				offset += size
+			}
+		}
+		return offset, nil
+	}
+
+	switch one := clType.(type) {
+	case *cltype.Option:
+		if len(data) < 1 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if data[0] == 0 {
+			return 1, nil
+		}
+		return sequence(1, 1, one.Inner)
+	case *cltype.List:
+		length, err := readLength()
+		if err != nil {
+			return 0, err
+		}
+		return sequence(4, length, one.ElementsType)
+	case *cltype.Map:
+		length, err := readLength()
+		if err != nil {
+			return 0, err
+		}
+		return sequence(4, length, one.Key, one.Val)
+	case *cltype.ByteArray:
+		return next(int(one.Size))
+	case *cltype.Result:
+		if len(data) < 1 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if data[0] == 1 {
+			return sequence(1, 1, one.InnerOk)
+		}
+		return sequence(1, 1, one.InnerErr)
+	case *cltype.Tuple1:
+		return sequence(0, 1, one.Inner1)
+	case *cltype.Tuple2:
+		return sequence(0, 1, one.Inner1, one.Inner2)
+	case *cltype.Tuple3:
+		return sequence(0, 1, one.Inner1, one.Inner2, one.Inner3)
+	}
+
+	switch clType.GetTypeID() {
+	case cltype.TypeIDBool, cltype.TypeIDU8:
+		return next(1)
+	case cltype.TypeIDI32, cltype.TypeIDU32:
+		return next(4)
+	case cltype.TypeIDI64, cltype.TypeIDU64:
+		return next(8)
+	case cltype.TypeIDU128, cltype.TypeIDU256, cltype.TypeIDU512:
+		if len(data) < 1 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return next(1 + int(data[0]))
+	case cltype.TypeIDUnit:
+		return 0, nil
+	case cltype.TypeIDString:
+		length, err := readLength()
+		if err != nil {
+			return 0, err
+		}
+		return next(4 + length)
+	case cltype.TypeIDURef:
+		return next(33)
+	case cltype.TypeIDKey, cltype.TypeIDPublicKey:
+		buf := bytes.NewBuffer(data)
+		if _, err := clvalue.FromBufferByType(buf, clType); err != nil {
+			return 0, err
+		}
+		return len(data) - buf.Len(), nil
+	default:
+		// the size of Any is unknown, the rest of data is left to the decoder
+		return len(data), nil
+	}
+}
+
+// checkSchemasBytes walk the encoded schemas Map<String, List<Tuple2<String, CLType>>> checking the limits before
+// the SDK decoder allocates the schemas
+func (l DecodeLimits) checkSchemasBytes(rawSchemas []byte) error {
+	if err := checkLimit(LimitSchemaSize, len(rawSchemas), l.MaxSchemaSize); err != nil {
+		return err
+	}
+
+	data := rawSchemas
+	readLength := func() (int, error) {
+		if len(data) < 4 {
+			return 0, ErrInvalidSchemaFormat
+		}
+		length := int(binary.LittleEndian.Uint32(data))
+		data = data[4:]
+		if err := checkLimit(LimitCollectionLength, length, l.MaxCollectionLength); err != nil {
+			return 0, err
+		}
+		return length, nil
+	}
+	readString := func() error {
+		length, err := readLength()
+		if err != nil {
+			return err
+		}
+		if length > len(data) {
+			return ErrInvalidSchemaFormat
+		}
+		data = data[length:]
+		return nil
+	}
+
+	events, err := readLength()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < events; i++ {
+		if err = readString(); err != nil {
+			return err
+		}
+
+		fields, err := readLength()
+		if err != nil {
+			return err
+		}
+		for j := 0; j < fields; j++ {
+			if err = readString(); err != nil {
+				return err
+			}
+
+			size, err := l.checkCLTypeBytes(data, 1)
+			if err != nil {
+				return err
+			}
+			data = data[size:]
+		}
+	}
+	return nil
+}
+
+// checkCLTypeBytes walk the encoded CLType checking the nesting depth, returns the size of the encoded CLType
+func (l DecodeLimits) checkCLTypeBytes(data []byte, depth int) (int, error) {
+	if err := checkLimit(LimitNestingDepth, depth, l.MaxNestingDepth); err != nil {
+		return 0, err
+	}
+	if len(data) < 1 {
+		return 0, ErrInvalidSchemaFormat
+	}
+
+	inner := 0
+	switch cltype.TypeID(data[0]) {
+	case cltype.TypeIDOption, cltype.TypeIDList, cltype.TypeIDTuple1:
+		inner = 1
+	case cltype.TypeIDResult, cltype.TypeIDMap, cltype.TypeIDTuple2:
+		inner = 2
+	case cltype.TypeIDTuple3:
+		inner = 3
+	case cltype.TypeIDByteArray:
+		if len(data) < 5 {
+			return 0, ErrInvalidSchemaFormat
+		}
+		if err := checkLimit(LimitCollectionLength, int(binary.LittleEndian.Uint32(data[1:])), l.MaxCollectionLength); err != nil {
+			return 0, err
+		}
+		return 5, nil
+	case cltype.TypeIDBool, cltype.TypeIDI32, cltype.TypeIDI64, cltype.TypeIDU8, cltype.TypeIDU32, cltype.TypeIDU64,
+		cltype.TypeIDU128, cltype.TypeIDU256, cltype.TypeIDU512, cltype.TypeIDUnit, cltype.TypeIDString,
+		cltype.TypeIDKey, cltype.TypeIDURef, cltype.TypeIDAny, cltype.TypeIDPublicKey:
+		return 1, nil
+	default:
+		return 0, ErrInvalidSchemaFormat
+	}
+
+	offset := 1
+	for i := 0; i < inner; i++ {
+		size, err := l.checkCLTypeBytes(data[offset:], depth+1)
+		if err != nil {
+			return 0, err
+		}
+		offset += size
+	}
+	return offset, nil
+}
@@ -0,0 +1,191 @@
+package ces
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// limitsTestSchema has fields of every length prefixed kind
+var limitsTestSchema = []SchemaData{
+	{ParamName: "name", ParamType: cltype.String},
+	{ParamName: "bytes", ParamType: &cltype.List{ElementsType: cltype.UInt8}},
+	{ParamName: "balances", ParamType: &cltype.Map{Key: cltype.String, Val: cltype.UInt32}},
+	{ParamName: "hash", ParamType: &cltype.Option{Inner: &cltype.ByteArray{Size: 4}}},
+}
+
+func lengthPrefix(length uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, length)
+}
+
+// encodeTestSchemas encode single event schema with the field of the encoded CLType
+func encodeTestSchemas(clTypeBytes []byte) []byte {
+	encoded := lengthPrefix(1)
+	encoded = append(append(encoded, lengthPrefix(1)...), 'e')
+	encoded = append(encoded, lengthPrefix(1)...)
+	encoded = append(append(encoded, lengthPrefix(1)...), 'f')
+	return append(encoded, clTypeBytes...)
+}
+
+func TestDecodeLimits(t *testing.T) {
+	t.Run("Valid payload", func(t *testing.T) {
+		payload := append(lengthPrefix(3), "abc"...)
+		payload = append(append(payload, lengthPrefix(2)...), 1, 2)
+		payload = append(payload, lengthPrefix(1)...)
+		payload = append(append(payload, lengthPrefix(1)...), 'a')
+		payload = append(payload, lengthPrefix(7)...)
+		payload = append(payload, 1, 0xde, 0xad, 0xbe, 0xef)
+
+		data, err := ParseEventDataFromSchemaBytes(limitsTestSchema, bytes.NewBuffer(payload))
+		require.NoError(t, err)
+		assert.Equal(t, "abc", data["name"].String())
+	})
+
+	t.Run("Large string", func(t *testing.T) {
+		// strings longer than 64 KiB fitting the payload are decoded with the default limits
+		name := strings.Repeat("a", 100<<10)
+		payload := append(lengthPrefix(uint32(len(name))), name...)
+		payload = append(payload, lengthPrefix(0)...)
+		payload = append(payload, lengthPrefix(0)...)
+		payload = append(payload, 0)
+
+		data, err := ParseEventDataFromSchemaBytes(limitsTestSchema, bytes.NewBuffer(payload))
+		require.NoError(t, err)
+		assert.Equal(t, name, data["name"].String())
+	})
+
+	t.Run("Collection length", func(t *testing.T) {
+		for _, payload := range [][]byte{
+			lengthPrefix(0xffffffff),
+			append(append(lengthPrefix(0), lengthPrefix(1<<30)...), 0),
+			append(append(lengthPrefix(0), lengthPrefix(0)...), lengthPrefix(1<<21)...),
+		} {
+			_, err := ParseEventDataFromSchemaBytes(limitsTestSchema, bytes.NewBuffer(payload))
+			var limitErr *DecodeLimitError
+			require.ErrorAs(t, err, &limitErr)
+			assert.Equal(t, LimitCollectionLength, limitErr.Limit)
+			assert.Equal(t, DefaultDecodeLimits.MaxCollectionLength, limitErr.Max)
+			assert.ErrorIs(t, err, ErrDecodeLimitExceeded)
+		}
+	})
+
+	t.Run("Length prefix beyond payload", func(t *testing.T) {
+		payload := append(lengthPrefix(0), lengthPrefix(1000)...)
+		_, err := ParseEventDataFromSchemaBytes(limitsTestSchema, bytes.NewBuffer(payload))
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "bytes", parseErr.FieldName)
+		assert.NotErrorIs(t, err, ErrDecodeLimitExceeded)
+	})
+
+	t.Run("Nesting depth", func(t *testing.T) {
+		var nested cltype.CLType = cltype.UInt8
+		for i := 0; i < DefaultDecodeLimits.MaxNestingDepth; i++ {
+			nested = &cltype.Option{Inner: nested}
+		}
+
+		_, err := ParseEventDataFromSchemaBytes([]SchemaData{{ParamName: "nested", ParamType: nested}}, bytes.NewBuffer([]byte{0}))
+		var limitErr *DecodeLimitError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, LimitNestingDepth, limitErr.Limit)
+	})
+
+	t.Run("Payload size", func(t *testing.T) {
+		rawEvent, err := hex.DecodeString(ballotCastEventHex)
+		require.NoError(t, err)
+
+		_, err = newDictionary(rawEvent, DecodeLimits{MaxPayloadSize: len(rawEvent) - 1})
+		var limitErr *DecodeLimitError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, LimitPayloadSize, limitErr.Limit)
+
+		_, err = newDictionary(rawEvent, DecodeLimits{MaxPayloadSize: len(rawEvent)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Schema size", func(t *testing.T) {
+		schemaBytes, err := hex.DecodeString(votingSchemaHex)
+		require.NoError(t, err)
+
+		_, err = newSchemasFromBytes(schemaBytes, DecodeLimits{MaxSchemaSize: len(schemaBytes) - 1})
+		var limitErr *DecodeLimitError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, LimitSchemaSize, limitErr.Limit)
+	})
+
+	t.Run("Schema nesting depth", func(t *testing.T) {
+		clTypeBytes := bytes.Repeat([]byte{byte(cltype.TypeIDOption)}, 1000)
+		_, err := NewSchemasFromBytes(encodeTestSchemas(append(clTypeBytes, byte(cltype.TypeIDU8))))
+		var limitErr *DecodeLimitError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, LimitNestingDepth, limitErr.Limit)
+	})
+
+	t.Run("Schema collection length", func(t *testing.T) {
+		_, err := NewSchemasFromBytes(lengthPrefix(0xffffffff))
+		assert.ErrorIs(t, err, ErrDecodeLimitExceeded)
+
+		clTypeBytes := append([]byte{byte(cltype.TypeIDByteArray)}, lengthPrefix(0xffffffff)...)
+		_, err = NewSchemasFromBytes(encodeTestSchemas(clTypeBytes))
+		assert.ErrorIs(t, err, ErrDecodeLimitExceeded)
+	})
+
+	t.Run("Parser limits", func(t *testing.T) {
+		rawEvent, err := hex.DecodeString(ballotCastEventHex)
+		require.NoError(t, err)
+
+		parser := &EventParser{}
+		assert.Equal(t, DefaultDecodeLimits, parser.limits())
+
+		WithDecodeLimits(DecodeLimits{MaxPayloadSize: 16})(parser)
+		_, err = parseEventMetadataFromDictionaryBytes(rawEvent, parser.limits())
+		assert.ErrorIs(t, err, ErrDecodeLimitExceeded)
+	})
+}
+
+func FuzzNewDictionary(f *testing.F) {
+	rawEvent, err := hex.DecodeString(ballotCastEventHex)
+	require.NoError(f, err)
+	f.Add(rawEvent)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		dictionary, err := newDictionary(data, DefaultDecodeLimits)
+		if err == nil {
+			assert.LessOrEqual(t, len(dictionary.Data), len(data))
+		}
+	})
+}
+
+func FuzzParseEventDataFromSchemaBytes(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(append(lengthPrefix(1), 'a'))
+	f.Add(append(lengthPrefix(0), lengthPrefix(0xffffffff)...))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, err := ParseEventDataFromSchemaBytes(limitsTestSchema, bytes.NewBuffer(data))
+		if err != nil {
+			var parseErr *ParseError
+			assert.ErrorAs(t, err, &parseErr)
+		}
+	})
+}
+
+func FuzzNewSchemasFromBytes(f *testing.F) {
+	schemaBytes, err := hex.DecodeString(votingSchemaHex)
+	require.NoError(f, err)
+	f.Add(schemaBytes)
+	f.Add(encodeTestSchemas([]byte{byte(cltype.TypeIDList), byte(cltype.TypeIDString)}))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		schemas, err := NewSchemasFromBytes(data)
+		if err == nil {
+			assert.LessOrEqual(t, len(schemas), len(data))
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package ces
+
+import (
+	"fmt"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+)
+
+// ContractLoadError wraps the error of loading metadata of the contract failed with WithPartialLoad option
+type ContractLoadError struct {
+	ContractHash casper.Hash
+	Err          error
+}
+
+func (e *ContractLoadError) Error() string {
+	return fmt.Sprintf("contract %s: %s", e.ContractHash.ToHex(), e.Err)
+}
+
+func (e *ContractLoadError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,75 @@
+package ces
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+)
+
+// Attribute keys of the records EventParser logs, see WithLogger
+const (
+	LogKeyContractHash = "contract_hash"
+	LogKeyTransformID  = "transform_id"
+	LogKeyEventName    = "event_name"
+	LogKeyEventsURef   = "events_uref"
+	LogKeyReason       = "reason"
+	LogKeyEvents       = "events"
+	LogKeyError        = "error"
+)
+
+// logEnabled check the parser has the logger accepting records of the level, attributes are built only if it does
+func (p *EventParser) logEnabled(level slog.Level) bool {
+	return p.logger != nil && p.logger.Enabled(context.Background(), level)
+}
+
+// skippedTransform is the context of the transform skipped by ParseExecutionResults, the fields set depend on the reason
+type skippedTransform struct {
+	transformID  uint
+	reason       SkipReason
+	err          error
+	eventsURef   casper.Uref
+	contractHash casper.Hash
+	eventName    string
+}
+
+// skipTransform report the transform skipped by ParseExecutionResults to the observer and the logger,
+// the log attributes are built only if the logger accepts debug records
+func (p *EventParser) skipTransform(skipped skippedTransform) {
+	p.observe().TransformSkipped(skipped.transformID, skipped.reason)
+	if !p.logEnabled(slog.LevelDebug) {
+		return
+	}
+
+	attrs := make([]slog.Attr, 0, 4)
+	switch skipped.reason {
+	case SkipReasonNotEvent:
+		attrs = append(attrs, slog.String(LogKeyError, skipped.err.Error()))
+	case SkipReasonUnknownContract:
+		attrs = append(attrs, slog.String(LogKeyEventsURef, skipped.eventsURef.String()), slog.String(LogKeyEventName, skipped.eventName))
+	case SkipReasonFiltered:
+		attrs = append(attrs, slog.String(LogKeyContractHash, skipped.contractHash.ToHex()), slog.String(LogKeyEventName, skipped.eventName))
+	}
+
+	attrs = append(attrs, slog.Uint64(LogKeyTransformID, uint64(skipped.transformID)), slog.String(LogKeyReason, string(skipped.reason)))
+	p.logger.LogAttrs(context.Background(), slog.LevelDebug, "transform skipped", attrs...)
+}
+
+// contractSchemasLoaded report schemas loaded by NewParser or replaced by the execution result
+// to the observer and the logger
+func (p *EventParser) contractSchemasLoaded(contractHash casper.Hash, schemas Schemas, err error, attrs ...slog.Attr) {
+	p.observe().SchemaLoaded(contractHash, err)
+	if !p.logEnabled(slog.LevelInfo) {
+		return
+	}
+
+	attrs = append(attrs, slog.String(LogKeyContractHash, contractHash.ToHex()))
+	if err != nil {
+		attrs = append(attrs, slog.String(LogKeyError, err.Error()))
+		p.logger.LogAttrs(context.Background(), slog.LevelInfo, "contract schemas load failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs, slog.Int(LogKeyEvents, len(schemas)))
+	p.logger.LogAttrs(context.Background(), slog.LevelInfo, "contract schemas loaded", attrs...)
+}
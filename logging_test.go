@@ -0,0 +1,124 @@
+package ces
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2/utils/mocks"
+)
+
+func decodeLogRecords(t *testing.T, output *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(output)
+	for decoder.More() {
+		var record map[string]interface{}
+		require.NoError(t, decoder.Decode(&record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogger(t *testing.T) {
+	data, err := os.ReadFile("./utils/fixtures/deploys/voting_created.json")
+	require.NoError(t, err)
+
+	var fixture struct {
+		ExecutionResults []types.DeployExecutionResult `json:"execution_results"`
+	}
+	require.NoError(t, json.Unmarshal(data, &fixture))
+	executionResult := types.DeployExecutionInfoFromV1(fixture.ExecutionResults, nil).ExecutionResult
+
+	metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	withoutBallotCast := metadata
+	withoutBallotCast.Schemas = Schemas{"SimpleVotingCreated": metadata.Schemas["SimpleVotingCreated"]}
+
+	t.Run("Debug records", func(t *testing.T) {
+		var output bytes.Buffer
+		eventParser := EventParser{
+			contractsMetadata: map[string]ContractMetadata{metadata.EventsURef.String(): withoutBallotCast},
+			filter:            &EventFilter{EventNames: []EventName{"BallotCast"}},
+		}
+		WithLogger(slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug})))(&eventParser)
+
+		_, err := eventParser.ParseExecutionResults(executionResult)
+		require.NoError(t, err)
+
+		var failed, filtered []map[string]interface{}
+		for _, record := range decodeLogRecords(t, &output) {
+			assert.Equal(t, "DEBUG", record["level"])
+			assert.Contains(t, record, LogKeyTransformID)
+			switch {
+			case record["msg"] == "event decode failed":
+				failed = append(failed, record)
+			case record[LogKeyReason] == string(SkipReasonFiltered):
+				filtered = append(filtered, record)
+			}
+		}
+
+		require.Len(t, failed, 1)
+		assert.Equal(t, "BallotCast", failed[0][LogKeyEventName])
+		assert.Equal(t, metadata.ContractHash.ToHex(), failed[0][LogKeyContractHash])
+		assert.Contains(t, failed[0][LogKeyError], ErrEventNameNotInSchema.Error())
+
+		require.Len(t, filtered, 1)
+		assert.Equal(t, "SimpleVotingCreated", filtered[0][LogKeyEventName])
+	})
+
+	t.Run("Info level", func(t *testing.T) {
+		var output bytes.Buffer
+		eventParser := EventParser{
+			contractsMetadata: map[string]ContractMetadata{metadata.EventsURef.String(): withoutBallotCast},
+			logger:            slog.New(slog.NewJSONHandler(&output, nil)),
+		}
+
+		_, err := eventParser.ParseExecutionResults(executionResult)
+		require.NoError(t, err)
+		assert.Zero(t, output.Len())
+	})
+
+	t.Run("Skipped transforms without logger", func(t *testing.T) {
+		var eventParser EventParser
+		notEventErr := errors.New("not an event")
+
+		// the log attributes are not built if the parser has no logger
+		allocs := testing.AllocsPerRun(100, func() {
+			eventParser.skipTransform(skippedTransform{transformID: 1, reason: SkipReasonNotEvent, err: notEventErr})
+			eventParser.skipTransform(skippedTransform{transformID: 2, reason: SkipReasonUnknownContract,
+				eventsURef: metadata.EventsURef, eventName: "BallotCast"})
+			eventParser.skipTransform(skippedTransform{transformID: 3, reason: SkipReasonFiltered,
+				contractHash: metadata.ContractHash, eventName: "BallotCast"})
+		})
+		assert.Zero(t, allocs)
+	})
+
+	t.Run("Contract loading", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockedClient := mocks.NewMockStateReader(mockCtrl)
+		rpcErr := errors.New("node is unavailable")
+		mockedClient.EXPECT().GetStateRootHashLatest(gomock.Any()).Return(casper.ChainGetStateRootHashResult{}, rpcErr)
+
+		var output bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&output, nil))
+		_, err := NewParser(mockedClient, []casper.Hash{metadata.ContractHash}, WithLogger(logger))
+		assert.ErrorIs(t, err, rpcErr)
+
+		records := decodeLogRecords(t, &output)
+		require.Len(t, records, 1)
+		assert.Equal(t, "INFO", records[0]["level"])
+		assert.Equal(t, "contract schemas load failed", records[0]["msg"])
+		assert.Equal(t, metadata.ContractHash.ToHex(), records[0][LogKeyContractHash])
+		assert.Equal(t, rpcErr.Error(), records[0][LogKeyError])
+	})
+}
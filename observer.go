@@ -0,0 +1,123 @@
+package ces
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/rpc"
+)
+
+const (
+	ErrorClassUnknownEvent     ErrorClass = "unknown_event"
+	ErrorClassTruncatedPayload ErrorClass = "truncated_payload"
+	ErrorClassLimitExceeded    ErrorClass = "limit_exceeded"
+	ErrorClassDecode           ErrorClass = "decode"
+)
+
+const (
+	// SkipReasonNotEvent is reported for WriteCLValue transforms which are not CES event dictionary items
+	SkipReasonNotEvent SkipReason = "not_event"
+	// SkipReasonUnknownContract is reported for events of contracts the parser does not observe
+	SkipReasonUnknownContract SkipReason = "unknown_contract"
+	// SkipReasonFiltered is reported for events rejected by the EventFilter
+	SkipReasonFiltered SkipReason = "filtered"
+)
+
+type (
+	// ErrorClass is the low cardinality kind of event decoding failure, see ClassifyParseError
+	ErrorClass string
+
+	// SkipReason tells why ParseExecutionResults skipped the transform
+	SkipReason string
+
+	// Observer receives EventParser activity, implementations must be safe for concurrent use.
+	// Embed NopObserver to implement a part of the callbacks only.
+	Observer interface {
+		// EventParsed is called for every successfully decoded event
+		EventParsed(event Event)
+		// EventFailed is called for every event of observed contract which failed to decode
+		EventFailed(contractHash casper.Hash, eventName string, class ErrorClass, err error)
+		// TransformSkipped is called for WriteCLValue transforms which are not parsed, other transforms are not reported
+		TransformSkipped(transformID uint, reason SkipReason)
+		// SchemaLoaded is called when contract schemas are loaded by NewParser or replaced by the execution result
+		SchemaLoaded(contractHash casper.Hash, err error)
+		// RPCCompleted is called after every RPC call of the parser with the method name of StateReader
+		RPCCompleted(method string, duration time.Duration, err error)
+	}
+
+	NopObserver struct{}
+
+	// observingStateReader reports latency of RPC methods used by the library
+	observingStateReader struct {
+		StateReader
+		observer Observer
+	}
+)
+
+func (NopObserver) EventParsed(Event)                                  {}
+func (NopObserver) EventFailed(casper.Hash, string, ErrorClass, error) {}
+func (NopObserver) TransformSkipped(uint, SkipReason)                  {}
+func (NopObserver) SchemaLoaded(casper.Hash, error)                    {}
+func (NopObserver) RPCCompleted(string, time.Duration, error)          {}
+
+// ClassifyParseError return the ErrorClass of ParseResult.Error
+func ClassifyParseError(err error) ErrorClass {
+	switch {
+	case errors.Is(err, ErrEventNameNotInSchema):
+		return ErrorClassUnknownEvent
+	case errors.Is(err, ErrDecodeLimitExceeded):
+		return ErrorClassLimitExceeded
+	case errors.Is(err, ErrEventPayloadTooShort), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorClassTruncatedPayload
+	default:
+		return ErrorClassDecode
+	}
+}
+
+func (p *EventParser) observe() Observer {
+	if p.observer == nil {
+		return NopObserver{}
+	}
+	return p.observer
+}
+
+// observeResult report the parsed event or its failure
+func (p *EventParser) observeResult(result ParseResult) {
+	if result.Error != nil {
+		p.observe().EventFailed(result.Event.ContractHash, result.Event.Name, ClassifyParseError(result.Error), result.Error)
+		return
+	}
+	p.observe().EventParsed(result.Event)
+}
+
+func newObservingStateReader(reader StateReader, observer Observer) StateReader {
+	return &observingStateReader{StateReader: reader, observer: observer}
+}
+
+func (c *observingStateReader) GetStateRootHashLatest(ctx context.Context) (rpc.ChainGetStateRootHashResult, error) {
+	return observeCall(c.observer, "GetStateRootHashLatest", func() (rpc.ChainGetStateRootHashResult, error) {
+		return c.StateReader.GetStateRootHashLatest(ctx)
+	})
+}
+
+func (c *observingStateReader) QueryGlobalStateByStateHash(ctx context.Context, stateRootHash *string, key string, path []string) (rpc.QueryGlobalStateResult, error) {
+	return observeCall(c.observer, "QueryGlobalStateByStateHash", func() (rpc.QueryGlobalStateResult, error) {
+		return c.StateReader.QueryGlobalStateByStateHash(ctx, stateRootHash, key, path)
+	})
+}
+
+func (c *observingStateReader) GetDictionaryItem(ctx context.Context, stateRootHash *string, uref, key string) (rpc.StateGetDictionaryResult, error) {
+	return observeCall(c.observer, "GetDictionaryItem", func() (rpc.StateGetDictionaryResult, error) {
+		return c.StateReader.GetDictionaryItem(ctx, stateRootHash, uref, key)
+	})
+}
+
+func observeCall[T any](observer Observer, method string, call func() (T, error)) (T, error) {
+	start := time.Now()
+	result, err := call()
+	observer.RPCCompleted(method, time.Since(start), err)
+	return result, err
+}
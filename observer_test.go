@@ -0,0 +1,142 @@
+package ces
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2/utils/mocks"
+)
+
+type recordingObserver struct {
+	NopObserver
+	mu       sync.Mutex
+	parsed   []string
+	failed   map[ErrorClass]int
+	skipped  map[SkipReason]int
+	rpcCalls []string
+	loaded   []error
+}
+
+func newRecordingObserver() *recordingObserver {
+	return &recordingObserver{failed: make(map[ErrorClass]int), skipped: make(map[SkipReason]int)}
+}
+
+func (o *recordingObserver) EventParsed(event Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.parsed = append(o.parsed, event.Name)
+}
+
+func (o *recordingObserver) EventFailed(_ casper.Hash, _ string, class ErrorClass, _ error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.failed[class]++
+}
+
+func (o *recordingObserver) TransformSkipped(_ uint, reason SkipReason) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.skipped[reason]++
+}
+
+func (o *recordingObserver) SchemaLoaded(_ casper.Hash, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.loaded = append(o.loaded, err)
+}
+
+func (o *recordingObserver) RPCCompleted(method string, _ time.Duration, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.rpcCalls = append(o.rpcCalls, fmt.Sprintf("%s: %v", method, err))
+}
+
+func TestObserver(t *testing.T) {
+	data, err := os.ReadFile("./utils/fixtures/deploys/voting_created.json")
+	require.NoError(t, err)
+
+	var fixture struct {
+		ExecutionResults []types.DeployExecutionResult `json:"execution_results"`
+	}
+	require.NoError(t, json.Unmarshal(data, &fixture))
+	executionResult := types.DeployExecutionInfoFromV1(fixture.ExecutionResults, nil).ExecutionResult
+
+	metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+
+	t.Run("Parsed events", func(t *testing.T) {
+		observer := newRecordingObserver()
+		eventParser := EventParser{
+			contractsMetadata: map[string]ContractMetadata{metadata.EventsURef.String(): metadata},
+			observer:          observer,
+		}
+
+		_, err := eventParser.ParseExecutionResults(executionResult)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"BallotCast", "SimpleVotingCreated"}, observer.parsed)
+		assert.Empty(t, observer.failed)
+		assert.NotZero(t, observer.skipped[SkipReasonNotEvent])
+	})
+
+	t.Run("Failed and filtered events", func(t *testing.T) {
+		withoutBallotCast := metadata
+		withoutBallotCast.Schemas = Schemas{"SimpleVotingCreated": metadata.Schemas["SimpleVotingCreated"]}
+
+		observer := newRecordingObserver()
+		eventParser := EventParser{
+			contractsMetadata: map[string]ContractMetadata{metadata.EventsURef.String(): withoutBallotCast},
+			filter:            &EventFilter{EventNames: []EventName{"BallotCast"}},
+			observer:          observer,
+		}
+
+		_, err := eventParser.ParseExecutionResults(executionResult)
+		require.NoError(t, err)
+		assert.Empty(t, observer.parsed)
+		assert.Equal(t, 1, observer.failed[ErrorClassUnknownEvent])
+		assert.Equal(t, 1, observer.skipped[SkipReasonFiltered])
+	})
+
+	t.Run("Unknown contract", func(t *testing.T) {
+		observer := newRecordingObserver()
+		eventParser := EventParser{observer: observer}
+
+		_, err := eventParser.ParseExecutionResults(executionResult)
+		require.NoError(t, err)
+		assert.Equal(t, 2, observer.skipped[SkipReasonUnknownContract])
+	})
+
+	t.Run("RPC latency", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockedClient := mocks.NewMockStateReader(mockCtrl)
+		rpcErr := errors.New("node is unavailable")
+		mockedClient.EXPECT().GetStateRootHashLatest(gomock.Any()).Return(casper.ChainGetStateRootHashResult{}, rpcErr)
+
+		observer := newRecordingObserver()
+		_, err := NewParser(mockedClient, []casper.Hash{metadata.ContractHash}, WithObserver(observer))
+		assert.ErrorIs(t, err, rpcErr)
+		assert.Equal(t, []string{"GetStateRootHashLatest: node is unavailable"}, observer.rpcCalls)
+		require.Len(t, observer.loaded, 1)
+		assert.ErrorIs(t, observer.loaded[0], rpcErr)
+	})
+}
+
+func TestClassifyParseError(t *testing.T) {
+	assert.Equal(t, ErrorClassUnknownEvent, ClassifyParseError(&ParseError{Err: ErrEventNameNotInSchema}))
+	assert.Equal(t, ErrorClassTruncatedPayload, ClassifyParseError(&ParseError{Err: ErrEventPayloadTooShort}))
+	assert.Equal(t, ErrorClassTruncatedPayload, ClassifyParseError(&ParseError{Err: io.ErrUnexpectedEOF}))
+	assert.Equal(t, ErrorClassLimitExceeded, ClassifyParseError(&ParseError{Err: &DecodeLimitError{Limit: LimitNestingDepth}}))
+	assert.Equal(t, ErrorClassDecode, ClassifyParseError(&ParseError{Err: errors.New("invalid key tag")}))
+}
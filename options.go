@@ -0,0 +1,114 @@
+package ces
+
+import "log/slog"
+
+// ParserOption configures optional EventParser behaviour
+type ParserOption func(*EventParser)
+
+// WithEventFilter makes EventParser decode only events matching the provided filter,
+// other events are skipped before their payload is parsed
+func WithEventFilter(filter EventFilter) ParserOption {
+	return func(p *EventParser) {
+		p.filter = &filter
+	}
+}
+
+// WithFilteredEventHandler set the handler called for events dropped by WithEventFilter, e.g. EventTracker.Skip
+// to not take filtered event ids for gaps
+func WithFilteredEventHandler(handler FilteredEventHandler) ParserOption {
+	return func(p *EventParser) {
+		p.filteredEventHandler = handler
+	}
+}
+
+// WithSchemaStore makes EventParser keep contract metadata and event schemas loaded from the node in the store,
+// the entry is replaced if the loaded schemas differ from the stored ones
+func WithSchemaStore(store SchemaStore) ParserOption {
+	return func(p *EventParser) {
+		p.schemaStore = store
+	}
+}
+
+// WithSchemaChangedHandler set the handler called when EventParser detects rewrite of observed contract __events_schema
+func WithSchemaChangedHandler(handler SchemaChangedHandler) ParserOption {
+	return func(p *EventParser) {
+		p.schemaChangedHandler = handler
+	}
+}
+
+// WithoutSchemaRefresh makes ParseExecutionResults ignore rewrites of observed contracts __events_schema, the schemas
+// loaded by NewParser are kept. Use it if execution results come from untrusted sources.
+func WithoutSchemaRefresh() ParserOption {
+	return func(p *EventParser) {
+		p.schemaRefreshOff = true
+	}
+}
+
+// WithSchemaValidation makes EventParser validate schemas of the observed contracts on load and on schema changes
+func WithSchemaValidation(mode SchemaValidationMode) ParserOption {
+	return func(p *EventParser) {
+		p.schemaValidation = mode
+	}
+}
+
+// WithLoadWorkers set the number of contracts NewParser loads in parallel, contracts are loaded one by one by default
+func WithLoadWorkers(workers int) ParserOption {
+	return func(p *EventParser) {
+		p.loadWorkers = workers
+	}
+}
+
+// WithLoadRateLimit limit the number of RPC requests per second NewParser sends while loading contracts
+func WithLoadRateLimit(requestsPerSecond int) ParserOption {
+	return func(p *EventParser) {
+		p.loadRateLimit = requestsPerSecond
+	}
+}
+
+// WithPartialLoad makes NewParser skip contracts failed to load instead of failing. The parser observes the loaded
+// contracts, the failed ones are reported by EventParser.FailedContracts.
+func WithPartialLoad() ParserOption {
+	return func(p *EventParser) {
+		p.partialLoad = true
+	}
+}
+
+// WithRetryPolicy makes EventParser retry failed RPC calls of schema loading, event fetching and scanning
+// according to the policy
+func WithRetryPolicy(policy RetryPolicy) ParserOption {
+	return func(p *EventParser) {
+		p.stateReader = newRetryingStateReader(p.stateReader, policy)
+	}
+}
+
+// WithBatchWorkers set the number of workers ParseExecutionResultsBatch uses, GOMAXPROCS by default
+func WithBatchWorkers(workers int) ParserOption {
+	return func(p *EventParser) {
+		p.batchWorkers = workers
+	}
+}
+
+// WithDecodeLimits replace DefaultDecodeLimits for event payloads and schemas decoded by EventParser
+func WithDecodeLimits(limits DecodeLimits) ParserOption {
+	return func(p *EventParser) {
+		p.decodeLimits = &limits
+	}
+}
+
+// WithObserver reports parsed and failed events, skipped transforms, loaded schemas and RPC latency to the observer.
+// RPC calls are measured around the StateReader configured by the preceding options, put WithObserver before
+// WithRetryPolicy to measure every attempt.
+func WithObserver(observer Observer) ParserOption {
+	return func(p *EventParser) {
+		p.observer = observer
+		p.stateReader = newObservingStateReader(p.stateReader, observer)
+	}
+}
+
+// WithLogger makes EventParser log contract loading at info level and skipped transforms and decoding failures
+// of ParseExecutionResults at debug level, see LogKeyContractHash and other attribute keys
+func WithLogger(logger *slog.Logger) ParserOption {
+	return func(p *EventParser) {
+		p.logger = logger
+	}
+}
@@ -0,0 +1,65 @@
+package ces
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+)
+
+// ParseError describes where the event decoding failed, it wraps the cause so errors.Is matches the sentinel errors
+// such as ErrEventNameNotInSchema. Fields unknown at the failure point are left empty.
+type ParseError struct {
+	ContractHash casper.Hash
+	// TransformID is the index of the transform in the execution result, set if HasTransformID
+	TransformID    uint
+	HasTransformID bool
+	EventName      string
+	FieldName      string
+	FieldType      cltype.CLType
+	// Offset is the byte offset in the CES event payload (see Event.RawBytes) where the failed value starts
+	Offset int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	var context []string
+	if e.ContractHash != (casper.Hash{}) {
+		context = append(context, "contract "+e.ContractHash.ToHex())
+	}
+	if e.HasTransformID {
+		context = append(context, fmt.Sprintf("transform %d", e.TransformID))
+	}
+	if e.EventName != "" {
+		context = append(context, "event "+e.EventName)
+	}
+	if e.FieldName != "" {
+		context = append(context, "field "+e.FieldName)
+	}
+	if e.FieldType != nil {
+		context = append(context, FormatCLType(e.FieldType))
+	}
+	if e.FieldName != "" || e.Offset > 0 {
+		context = append(context, fmt.Sprintf("offset %d", e.Offset))
+	}
+
+	if len(context) == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %s", strings.Join(context, " "), e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// asParseError return err if it is *ParseError or wrap it into the new one
+func asParseError(err error) *ParseError {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr
+	}
+	return &ParseError{Err: err}
+}
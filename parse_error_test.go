@@ -0,0 +1,56 @@
+package ces
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseError(t *testing.T) {
+	t.Run("Field context", func(t *testing.T) {
+		schemas, err := NewSchemasFromText("event BallotCast { voter: Key, voting_id: U32, voting_type: U8, choice: U8, stake: ByteArray(16) }")
+		require.NoError(t, err)
+
+		_, _, err = ParseEventNameAndData(ballotCastEventHex, schemas)
+
+		var parseErr *ParseError
+		require.True(t, errors.As(err, &parseErr))
+		assert.Equal(t, "BallotCast", parseErr.EventName)
+		assert.Equal(t, "stake", parseErr.FieldName)
+		assert.Equal(t, &cltype.ByteArray{Size: 16}, parseErr.FieldType)
+		// event name string of 4+16 bytes, voter key of 33 bytes, voting id, voting type and choice of 6 bytes
+		assert.Equal(t, 20+33+6, parseErr.Offset)
+		assert.Contains(t, parseErr.Error(), "event BallotCast field stake ByteArray(16) offset 59")
+	})
+
+	t.Run("Sentinel errors", func(t *testing.T) {
+		_, _, err := ParseEventNameAndData(ballotCastEventHex, Schemas{})
+		assert.ErrorIs(t, err, ErrEventNameNotInSchema)
+
+		var parseErr *ParseError
+		require.True(t, errors.As(err, &parseErr))
+		assert.Equal(t, "BallotCast", parseErr.EventName)
+	})
+
+	t.Run("Contract and transform context", func(t *testing.T) {
+		metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+		metadata.Schemas = Schemas{}
+
+		rawEvent, err := hex.DecodeString(ballotCastEventHex)
+		require.NoError(t, err)
+		eventMetadata, err := parseEventMetadataFromDictionaryBytes(rawEvent, DefaultDecodeLimits)
+		require.NoError(t, err)
+
+		result := parseEventWithMetadata(metadata, eventMetadata, DefaultDecodeLimits)
+		assert.ErrorIs(t, result.Error, ErrEventNameNotInSchema)
+
+		var parseErr *ParseError
+		require.True(t, errors.As(result.Error, &parseErr))
+		assert.Equal(t, metadata.ContractHash, parseErr.ContractHash)
+		assert.False(t, parseErr.HasTransformID)
+	})
+}
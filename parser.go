@@ -6,12 +6,13 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log/slog"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/make-software/casper-go-sdk/v2/casper"
-	"github.com/make-software/casper-go-sdk/v2/types/clvalue"
-	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
 )
 
 var (
@@ -23,27 +24,58 @@ var (
 	ErrMissingRequiredNamedKey          = errors.New("error: missing required named key")
 	ErrNoEventPrefixInEvent             = errors.New("error: no event_ prefix in event")
 	ErrNilDictionaryInTransform         = errors.New("error: nil dictionary in transform")
+	ErrContractNotObserved              = errors.New("error: contract is not observed by parser")
+	ErrInvalidEventIDRange              = errors.New("error: invalid event id range")
+	ErrEventIDRangeTooLarge             = errors.New("error: event id range is too large")
 )
 
+// MaxFetchEventsRange is the maximal number of events FetchEvents reads per call
+const MaxFetchEventsRange = 10_000
+
 const (
 	eventSchemaNamedKey = "__events_schema"
 	eventNamedKey       = "__events"
+	eventLengthNamedKey = "__events_length"
 	eventPrefix         = "event_"
 )
 
 type (
 	EventParser struct {
-		casperClient casper.RPCClient
+		stateReader StateReader
+		// mu guards contractsMetadata which is updated on schema changes
+		mu sync.RWMutex
 		// key represent Uref from __events named key
 		contractsMetadata map[string]ContractMetadata
+		// schemaURefs map __events_schema URefs of the observed contracts to the contractsMetadata keys,
+		// URefs are not changed by schema rewrites so the index is not updated after setContractsMetadata
+		schemaURefs map[casper.Uref]string
+		// failedContracts are filled with WithPartialLoad option only
+		failedContracts      []*ContractLoadError
+		filter               *EventFilter
+		filteredEventHandler FilteredEventHandler
+		schemaRefreshOff     bool
+		schemaStore          SchemaStore
+		schemaChangedHandler SchemaChangedHandler
+		schemaValidation     SchemaValidationMode
+		loadWorkers          int
+		loadRateLimit        int
+		partialLoad          bool
+		batchWorkers         int
+		// decodeLimits are DefaultDecodeLimits if nil
+		decodeLimits *DecodeLimits
+		observer     Observer
+		logger       *slog.Logger
 	}
 	EventName = string
 
 	EventMetadata struct {
-		Name    string
-		Uref    casper.Uref
+		Name string
+		Uref casper.Uref
+		// Payload is positioned at the event data following the event name
 		Payload *bytes.Buffer
 		EventID uint
+		// rawPayload is the whole CES event payload including the event name
+		rawPayload []byte
 	}
 
 	ContractMetadata struct {
@@ -52,26 +84,69 @@ type (
 		ContractPackageHash casper.Hash
 		EventsSchemaURef    casper.Uref
 		EventsURef          casper.Uref
+		// EventsLengthURef is empty if the contract does not store __events_length named key
+		EventsLengthURef casper.Uref
+		// SchemaIssues is filled with SchemaValidationWarn mode only
+		SchemaIssues []SchemaIssue
+		// SchemasFingerprint and EventFingerprints are calculated from Schemas, see SetSchemas
+		SchemasFingerprint SchemaFingerprint
+		EventFingerprints  map[EventName]SchemaFingerprint
+		// TokenStandard and StandardDeviations are detected from Schemas, see ClassifySchemas
+		TokenStandard      TokenStandard
+		StandardDeviations []StandardDeviation
+		// decodePlans are compiled from Schemas by SetSchemas, the plan is used if its fingerprint matches EventFingerprints
+		decodePlans map[EventName]eventDecodePlan
 	}
 )
 
-func NewParser(casperClient casper.RPCClient, contractHashes []casper.Hash) (*EventParser, error) {
-	eventParser := EventParser{
-		casperClient: casperClient,
+// NewParser create the parser loading metadata of the observed contracts, see NewParserContext
+func NewParser(stateReader StateReader, contractHashes []casper.Hash, opts ...ParserOption) (*EventParser, error) {
+	return NewParserContext(context.Background(), stateReader, contractHashes, opts...)
+}
+
+// NewParserContext create the parser loading metadata of the observed contracts, the context cancels RPC calls,
+// retry backoff and rate limit waiting of the loading
+func NewParserContext(ctx context.Context, stateReader StateReader, contractHashes []casper.Hash, opts ...ParserOption) (*EventParser, error) {
+	eventParser := &EventParser{
+		stateReader: stateReader,
+	}
+
+	for _, opt := range opts {
+		opt(eventParser)
 	}
 
-	contractsMetadata, err := eventParser.loadContractsMetadata(contractHashes)
+	contractsMetadata, failedContracts, err := eventParser.loadContractsMetadata(ctx, contractHashes)
 	if err != nil {
 		return nil, err
 	}
 
-	return &EventParser{
-		casperClient:      casperClient,
-		contractsMetadata: contractsMetadata,
-	}, nil
+	eventParser.setContractsMetadata(contractsMetadata)
+	eventParser.failedContracts = failedContracts
+	return eventParser, nil
+}
+
+// setContractsMetadata set metadata of the observed contracts together with the __events_schema URefs index
+func (p *EventParser) setContractsMetadata(contractsMetadata map[string]ContractMetadata) {
+	schemaURefs := make(map[casper.Uref]string, len(contractsMetadata))
+	for eventsURef, metadata := range contractsMetadata {
+		schemaURefs[metadata.EventsSchemaURef] = eventsURef
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.contractsMetadata = contractsMetadata
+	p.schemaURefs = schemaURefs
 }
 
-// ParseExecutionResults accept casper.ExecutionResult analyze its transforms and trying to parse events according to stored contract schema
+// FailedContracts return contracts failed to load with WithPartialLoad option in the NewParser contractHashes order
+func (p *EventParser) FailedContracts() []*ContractLoadError {
+	return p.failedContracts
+}
+
+// ParseExecutionResults accept casper.ExecutionResult analyze its transforms and trying to parse events according to stored contract schema.
+// The method mutates the parser state: a transform rewriting the __events_schema URef of an observed contract swaps
+// its schemas for all later calls, so execution results should be passed in the chain order.
 func (p *EventParser) ParseExecutionResults(executionResult casper.ExecutionResult) ([]ParseResult, error) {
 	if executionResult.ErrorMessage != nil {
 		return nil, ErrFailedDeploy
@@ -84,49 +159,68 @@ func (p *EventParser) ParseExecutionResults(executionResult casper.ExecutionResu
 			continue
 		}
 
-		eventMetadata, err := ParseEventMetadataFromTransform(transform)
-		if err != nil {
+		// schema is swapped before parsing the later transforms of the same execution result
+		if transform.Key.URef != nil {
+			if !p.schemaRefreshOff {
+				p.refreshContractSchemas(transform, uint(transformIDx))
+			}
 			continue
 		}
 
-		contractMetadata, ok := p.contractsMetadata[eventMetadata.Uref.String()]
-		if !ok {
+		eventMetadata, err := parseEventMetadataFromTransform(transform, p.limits())
+		if err != nil {
+			p.skipTransform(skippedTransform{transformID: uint(transformIDx), reason: SkipReasonNotEvent, err: err})
 			continue
 		}
 
-		parseResult := ParseResult{
-			Event: Event{
-				Name:        eventMetadata.Name,
-				TransformID: uint(transformIDx),
-				EventID:     eventMetadata.EventID,
-			},
-		}
-
-		eventSchema, ok := contractMetadata.Schemas[parseResult.Event.Name]
+		p.mu.RLock()
+		contractMetadata, ok := p.contractsMetadata[eventMetadata.Uref.String()]
+		p.mu.RUnlock()
 		if !ok {
-			parseResult.Error = ErrEventNameNotInSchema
-			results = append(results, parseResult)
+			p.skipTransform(skippedTransform{transformID: uint(transformIDx), reason: SkipReasonUnknownContract,
+				eventsURef: eventMetadata.Uref, eventName: eventMetadata.Name})
 			continue
 		}
 
-		eventData, err := ParseEventDataFromSchemaBytes(eventSchema, eventMetadata.Payload)
-		if err != nil {
-			parseResult.Error = err
-			results = append(results, parseResult)
+		// skip unwanted events before decoding their payload
+		if !p.filter.Match(contractMetadata, eventMetadata.Name) {
+			if p.filteredEventHandler != nil {
+				p.filteredEventHandler(contractMetadata.ContractHash, eventMetadata.EventID)
+			}
+			p.skipTransform(skippedTransform{transformID: uint(transformIDx), reason: SkipReasonFiltered,
+				contractHash: contractMetadata.ContractHash, eventName: eventMetadata.Name})
 			continue
 		}
 
-		parseResult.Event.ContractHash = contractMetadata.ContractHash
-		parseResult.Event.ContractPackageHash = contractMetadata.ContractPackageHash
-		parseResult.Event.RawData = hex.EncodeToString(eventMetadata.Payload.Bytes())
-		parseResult.Event.Data = eventData
+		parseResult := parseEventWithMetadata(contractMetadata, eventMetadata, p.limits())
+		parseResult.Event.TransformID = uint(transformIDx)
+		if parseResult.Error != nil {
+			parseErr := asParseError(parseResult.Error)
+			parseErr.TransformID, parseErr.HasTransformID = uint(transformIDx), true
+			parseResult.Error = parseErr
+
+			if p.logEnabled(slog.LevelDebug) {
+				p.logger.LogAttrs(context.Background(), slog.LevelDebug, "event decode failed",
+					slog.String(LogKeyContractHash, contractMetadata.ContractHash.ToHex()),
+					slog.Uint64(LogKeyTransformID, uint64(transformIDx)),
+					slog.String(LogKeyEventName, eventMetadata.Name),
+					slog.String(LogKeyError, parseErr.Error()))
+			}
+		}
+		p.observeResult(parseResult)
 		results = append(results, parseResult)
 	}
 
 	return results, nil
 }
 
+// ParseEventMetadataFromTransform parse event name, id and payload out of the __events dictionary transform
+// checking the payload against DefaultDecodeLimits
 func ParseEventMetadataFromTransform(transform casper.Transform) (EventMetadata, error) {
+	return parseEventMetadataFromTransform(transform, DefaultDecodeLimits)
+}
+
+func parseEventMetadataFromTransform(transform casper.Transform, limits DecodeLimits) (EventMetadata, error) {
 	writeCLValue, err := transform.Kind.ParseAsWriteCLValue()
 	if err != nil {
 		return EventMetadata{}, err
@@ -145,13 +239,17 @@ func ParseEventMetadataFromTransform(transform casper.Transform) (EventMetadata,
 		return EventMetadata{}, err
 	}
 
-	dictionary, err := newDictionary(rawBytes.Any.Bytes())
+	return parseEventMetadataFromDictionaryBytes(rawBytes.Any.Bytes(), limits)
+}
+
+// parseEventMetadataFromDictionaryBytes parse event name, id and payload out of the raw __events dictionary value
+func parseEventMetadataFromDictionaryBytes(rawBytes []byte, limits DecodeLimits) (EventMetadata, error) {
+	dictionary, err := newDictionary(rawBytes, limits)
 	if err != nil {
 		return EventMetadata{}, err
 	}
 
-	payload := bytes.NewBuffer(dictionary.DataToBytes())
-	eventNameWithPrefix, err := clvalue.FromBufferByType(payload, cltype.String)
+	eventNameWithPrefix, eventData, err := readLengthPrefixed(dictionary.Data)
 	if err != nil {
 		return EventMetadata{}, err
 	}
@@ -162,16 +260,63 @@ func ParseEventMetadataFromTransform(transform casper.Transform) (EventMetadata,
 	}
 
 	return EventMetadata{
-		Name:    strings.TrimPrefix(eventNameWithPrefix.String(), eventPrefix),
-		Uref:    dictionary.Uref,
-		Payload: payload,
-		EventID: uint(eventID),
+		Name:       strings.TrimPrefix(string(eventNameWithPrefix), eventPrefix),
+		Uref:       dictionary.Uref,
+		Payload:    bytes.NewBuffer(eventData),
+		EventID:    uint(eventID),
+		rawPayload: dictionary.Data,
 	}, nil
 }
 
+// parseEventWithMetadata decode event payload according to the contract schema of the event
+func parseEventWithMetadata(contractMetadata ContractMetadata, eventMetadata EventMetadata, limits DecodeLimits) ParseResult {
+	parseResult := ParseResult{
+		Event: Event{
+			ContractHash:        contractMetadata.ContractHash,
+			ContractPackageHash: contractMetadata.ContractPackageHash,
+			Name:                eventMetadata.Name,
+			EventID:             eventMetadata.EventID,
+		},
+	}
+
+	eventSchema, ok := contractMetadata.Schemas[parseResult.Event.Name]
+	if !ok {
+		parseResult.Error = &ParseError{
+			ContractHash: contractMetadata.ContractHash,
+			EventName:    eventMetadata.Name,
+			Err:          ErrEventNameNotInSchema,
+		}
+		return parseResult
+	}
+
+	// metadata built without SetSchemas has no compiled plans
+	plan, ok := contractMetadata.decodePlans[parseResult.Event.Name]
+	if !ok || plan.fingerprint != contractMetadata.EventFingerprints[parseResult.Event.Name] {
+		plan = compileDecodePlan(eventSchema)
+	}
+
+	payload := eventMetadata.Payload.Bytes()
+	eventData, rest, err := plan.decode(payload, len(eventMetadata.rawPayload)-len(payload), limits)
+	if err != nil {
+		parseErr := asParseError(err)
+		parseErr.ContractHash = contractMetadata.ContractHash
+		parseErr.EventName = eventMetadata.Name
+		parseResult.Error = parseErr
+		return parseResult
+	}
+
+	// RawData holds the payload bytes following the event data, it is empty for events matching the schema
+	if len(rest) > 0 {
+		parseResult.Event.RawData = hex.EncodeToString(rest)
+	}
+	parseResult.Event.rawPayload = eventMetadata.rawPayload
+	parseResult.Event.Data = eventData
+	return parseResult
+}
+
 // FetchContractSchemasBytes accept contract hash to fetch stored contract schema
 func (p *EventParser) FetchContractSchemasBytes(contractHash casper.Hash) ([]byte, error) {
-	schemasURefValue, err := p.casperClient.QueryGlobalStateByStateHash(context.Background(), nil, fmt.Sprintf("hash-%s", contractHash.ToHex()), []string{eventSchemaNamedKey})
+	schemasURefValue, err := p.stateReader.QueryGlobalStateByStateHash(context.Background(), nil, fmt.Sprintf("hash-%s", contractHash.ToHex()), []string{eventSchemaNamedKey})
 	if err != nil {
 		return nil, err
 	}
@@ -189,46 +334,294 @@ func (p *EventParser) FetchContractSchemasBytes(contractHash casper.Hash) ([]byt
 	return bytesData.Any.Bytes(), nil
 }
 
-func (p *EventParser) loadContractsMetadata(contractHashes []casper.Hash) (map[string]ContractMetadata, error) {
-	stateRootHash, err := p.casperClient.GetStateRootHashLatest(context.Background())
+// FetchEvent read event with provided id from the observed contract __events dictionary and parse it according to the contract schema
+func (p *EventParser) FetchEvent(ctx context.Context, contractHash casper.Hash, eventID uint) (ParseResult, error) {
+	contractMetadata, err := p.contractMetadataByHash(contractHash)
+	if err != nil {
+		return ParseResult{}, err
+	}
+
+	return p.fetchEvent(ctx, nil, contractMetadata, eventID)
+}
+
+// FetchEvents read events with ids in the inclusive range [fromID, toID] from the observed contract __events dictionary,
+// the range is limited by MaxFetchEventsRange
+func (p *EventParser) FetchEvents(ctx context.Context, contractHash casper.Hash, fromID, toID uint) ([]ParseResult, error) {
+	if fromID > toID {
+		return nil, ErrInvalidEventIDRange
+	}
+	if toID-fromID >= MaxFetchEventsRange {
+		return nil, ErrEventIDRangeTooLarge
+	}
+
+	contractMetadata, err := p.contractMetadataByHash(contractHash)
 	if err != nil {
 		return nil, err
 	}
 
-	stateRootString := stateRootHash.StateRootHash.ToHex()
-	contractsSchemas := make(map[string]ContractMetadata, len(contractHashes))
-	for _, hash := range contractHashes {
-		contractResult, err := p.casperClient.QueryGlobalStateByStateHash(context.Background(), &stateRootString, fmt.Sprintf("hash-%s", hash), nil)
+	results := make([]ParseResult, 0, toID-fromID+1)
+	for eventID := fromID; ; eventID++ {
+		result, err := p.fetchEvent(ctx, nil, contractMetadata, eventID)
 		if err != nil {
-			return nil, err
+			return results, err
+		}
+		results = append(results, result)
+		// toID can be the max uint value, the loop is not bounded by the condition to avoid overflow
+		if eventID == toID {
+			break
 		}
+	}
+
+	return results, nil
+}
 
-		if contractResult.StoredValue.Contract == nil {
-			return nil, ErrExpectContractStoredValue
+// contractMetadataByHash lookup observed contract metadata by the contract hash
+func (p *EventParser) contractMetadataByHash(contractHash casper.Hash) (ContractMetadata, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, metadata := range p.contractsMetadata {
+		if metadata.ContractHash == contractHash {
+			return metadata, nil
 		}
+	}
+	return ContractMetadata{}, ErrContractNotObserved
+}
 
-		contractMetadata, err := LoadContractMetadataWithoutSchema(*contractResult.StoredValue.Contract)
-		if err != nil {
-			return nil, err
+// fetchEvent read event with provided id from the contract __events dictionary and decode it,
+// the latest state is used if stateRootHash is nil
+func (p *EventParser) fetchEvent(ctx context.Context, stateRootHash *string, contractMetadata ContractMetadata, eventID uint) (ParseResult, error) {
+	dictionaryItem, err := p.stateReader.GetDictionaryItem(ctx, stateRootHash, contractMetadata.EventsURef.String(), strconv.FormatUint(uint64(eventID), 10))
+	if err != nil {
+		return ParseResult{}, err
+	}
+
+	value := dictionaryItem.StoredValue.CLValue
+	if value == nil {
+		return ParseResult{}, ErrExpectCLValueStoredValue
+	}
+
+	rawBytes, err := value.Value()
+	if err != nil {
+		return ParseResult{}, err
+	}
+
+	if rawBytes.Any == nil {
+		return ParseResult{}, ErrExpectCLValueStoredValue
+	}
+
+	eventMetadata, err := parseEventMetadataFromDictionaryBytes(rawBytes.Any.Bytes(), p.limits())
+	if err != nil {
+		return ParseResult{}, err
+	}
+
+	result := parseEventWithMetadata(contractMetadata, eventMetadata, p.limits())
+	p.observeResult(result)
+	return result, nil
+}
+
+func (p *EventParser) limits() DecodeLimits {
+	if p.decodeLimits == nil {
+		return DefaultDecodeLimits
+	}
+	return *p.decodeLimits
+}
+
+// loadContractsMetadata load metadata of contracts with the configured number of workers and RPC rate limit.
+// If several contracts fail, the error of the first one in contractHashes order is returned as is.
+// With WithPartialLoad option all contracts are loaded, metadata of the loaded ones is returned together with
+// the failed ones. Context and state root hash errors are returned in both modes.
+func (p *EventParser) loadContractsMetadata(ctx context.Context, contractHashes []casper.Hash) (map[string]ContractMetadata, []*ContractLoadError, error) {
+	limiter := newRateLimiter(p.loadRateLimit)
+	defer limiter.stop()
+
+	var (
+		stateRootOnce   sync.Once
+		stateRootString string
+		stateRootErr    error
+	)
+	// the latest state root hash is requested once, only if some contract is going to be loaded
+	stateRoot := func() (string, error) {
+		stateRootOnce.Do(func() {
+			if stateRootErr = limiter.wait(ctx); stateRootErr != nil {
+				return
+			}
+			stateRootHash, err := p.stateReader.GetStateRootHashLatest(ctx)
+			if err != nil {
+				stateRootErr = err
+				return
+			}
+			stateRootString = stateRootHash.StateRootHash.ToHex()
+		})
+		return stateRootString, stateRootErr
+	}
+
+	workers := p.loadWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var (
+		results = make([]ContractMetadata, len(contractHashes))
+		errs    = make([]error, len(contractHashes))
+		// firstFailed is the lowest index of failed contract, contracts after it are not loaded
+		firstFailed = int64(len(contractHashes))
+		jobs        = make(chan int)
+		wg          sync.WaitGroup
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				if !p.partialLoad && int64(idx) > atomic.LoadInt64(&firstFailed) {
+					continue
+				}
+
+				results[idx], errs[idx] = p.loadContract(ctx, contractHashes[idx], stateRoot, limiter)
+				p.contractSchemasLoaded(contractHashes[idx], results[idx].Schemas, errs[idx])
+				if errs[idx] == nil {
+					continue
+				}
+
+				for failed := atomic.LoadInt64(&firstFailed); int64(idx) < failed; failed = atomic.LoadInt64(&firstFailed) {
+					if atomic.CompareAndSwapInt64(&firstFailed, failed, int64(idx)) {
+						break
+					}
+				}
+			}
+		}()
+	}
+
+	for idx := range contractHashes {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	// the canceled context and the failed state root hash request are not failures of a single contract,
+	// nothing is loaded in this case with or without WithPartialLoad
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	if stateRootErr != nil {
+		return nil, nil, stateRootErr
+	}
+
+	var failedContracts []*ContractLoadError
+	contractsSchemas := make(map[string]ContractMetadata, len(contractHashes))
+	for idx, hash := range contractHashes {
+		if errs[idx] != nil {
+			if !p.partialLoad {
+				return nil, nil, errs[idx]
+			}
+			failedContracts = append(failedContracts, &ContractLoadError{ContractHash: hash, Err: errs[idx]})
+			continue
 		}
+		contractsSchemas[results[idx].EventsURef.String()] = results[idx]
+	}
 
-		schemas, err := LoadContractEventSchemas(p.casperClient, stateRootString, contractMetadata.EventsSchemaURef)
-		if err != nil {
-			return nil, ErrFailedToParseContractEventSchema
+	return contractsSchemas, failedContracts, nil
+}
+
+// loadContract load contract metadata and event schemas from the node. The schema store entry is replaced
+// if the contract refers to other __events_schema or __events URefs or the schemas differ from the stored ones,
+// e.g. the contract upgrade rewrote __events_schema in place.
+func (p *EventParser) loadContract(ctx context.Context, hash casper.Hash, stateRoot func() (string, error), limiter *rateLimiter) (ContractMetadata, error) {
+	stateRootString, err := stateRoot()
+	if err != nil {
+		return ContractMetadata{}, err
+	}
+
+	contractMetadata, err := p.loadContractMetadataWithoutSchema(ctx, stateRootString, hash, limiter)
+	if err != nil {
+		return ContractMetadata{}, err
+	}
+
+	if err = limiter.wait(ctx); err != nil {
+		return ContractMetadata{}, err
+	}
+	schemas, err := loadContractEventSchemas(ctx, p.stateReader, stateRootString, contractMetadata.EventsSchemaURef, p.limits())
+	if err != nil {
+		return ContractMetadata{}, fmt.Errorf("%w: %w", ErrFailedToParseContractEventSchema, err)
+	}
+	contractMetadata.SetSchemas(schemas)
+
+	if p.schemaStore != nil {
+		stored, err := p.schemaStore.Get(ctx, hash)
+		switch {
+		case err == nil && stored.EventsSchemaURef == contractMetadata.EventsSchemaURef &&
+			stored.EventsURef == contractMetadata.EventsURef && stored.SchemasFingerprint == contractMetadata.SchemasFingerprint:
+			// the stored entry is up to date
+		case err != nil && !errors.Is(err, ErrSchemaNotFound):
+			return ContractMetadata{}, err
+		default:
+			if err = p.schemaStore.Put(ctx, stateRootString, contractMetadata); err != nil {
+				return ContractMetadata{}, err
+			}
 		}
+	}
 
-		contractMetadata.ContractHash = hash
-		contractMetadata.Schemas = schemas
-		contractsSchemas[contractMetadata.EventsURef.String()] = contractMetadata
+	if err = p.validateContractSchemas(&contractMetadata); err != nil {
+		return ContractMetadata{}, err
 	}
 
-	return contractsSchemas, nil
+	return contractMetadata, nil
+}
+
+// validateContractSchemas check contract schemas according to the configured SchemaValidationMode
+func (p *EventParser) validateContractSchemas(contractMetadata *ContractMetadata) error {
+	switch p.schemaValidation {
+	case SchemaValidationWarn:
+		contractMetadata.SchemaIssues = contractMetadata.Schemas.Issues()
+	case SchemaValidationRefuse:
+		if err := contractMetadata.Schemas.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadContractMetadataWithoutSchema read the contract named keys at the provided state root hash
+func (p *EventParser) loadContractMetadataWithoutSchema(ctx context.Context, stateRootString string, hash casper.Hash, limiter *rateLimiter) (ContractMetadata, error) {
+	if err := limiter.wait(ctx); err != nil {
+		return ContractMetadata{}, err
+	}
+	contractResult, err := p.stateReader.QueryGlobalStateByStateHash(ctx, &stateRootString, fmt.Sprintf("hash-%s", hash), nil)
+	if err != nil {
+		return ContractMetadata{}, err
+	}
+
+	if contractResult.StoredValue.Contract == nil {
+		return ContractMetadata{}, ErrExpectContractStoredValue
+	}
+
+	contractMetadata, err := LoadContractMetadataWithoutSchema(*contractResult.StoredValue.Contract)
+	if err != nil {
+		return ContractMetadata{}, err
+	}
+
+	contractMetadata.ContractHash = hash
+	return contractMetadata, nil
+}
+
+// SetSchemas set contract schemas together with their fingerprints and detected token standard
+func (m *ContractMetadata) SetSchemas(schemas Schemas) {
+	m.Schemas = schemas
+	m.SchemasFingerprint = schemas.Fingerprint()
+	m.EventFingerprints = schemas.EventFingerprints()
+	m.decodePlans = compileDecodePlans(schemas, m.EventFingerprints)
+
+	classification := ClassifySchemas(schemas)
+	m.TokenStandard = classification.Standard
+	m.StandardDeviations = classification.Deviations
 }
 
 func LoadContractMetadataWithoutSchema(contractResult casper.Contract) (ContractMetadata, error) {
 	var (
 		eventsURefStr       string
 		eventsSchemaURefStr string
+		eventsLengthURefStr string
 	)
 
 	for _, namedKey := range contractResult.NamedKeys {
@@ -237,9 +630,11 @@ func LoadContractMetadataWithoutSchema(contractResult casper.Contract) (Contract
 			eventsURefStr = namedKey.Key.String()
 		case eventSchemaNamedKey:
 			eventsSchemaURefStr = namedKey.Key.String()
+		case eventLengthNamedKey:
+			eventsLengthURefStr = namedKey.Key.String()
 		}
 
-		if eventsURefStr != "" && eventsSchemaURefStr != "" {
+		if eventsURefStr != "" && eventsSchemaURefStr != "" && eventsLengthURefStr != "" {
 			break
 		}
 	}
@@ -258,15 +653,29 @@ func LoadContractMetadataWithoutSchema(contractResult casper.Contract) (Contract
 		return ContractMetadata{}, err
 	}
 
+	var eventsLengthURef casper.Uref
+	if eventsLengthURefStr != "" {
+		eventsLengthURef, err = casper.NewUref(eventsLengthURefStr)
+		if err != nil {
+			return ContractMetadata{}, err
+		}
+	}
+
 	return ContractMetadata{
 		ContractPackageHash: contractResult.ContractPackageHash.Hash,
 		EventsSchemaURef:    eventsSchemaURef,
 		EventsURef:          eventsURef,
+		EventsLengthURef:    eventsLengthURef,
 	}, nil
 }
 
-func LoadContractEventSchemas(casperClient casper.RPCClient, stateRootHash string, eventSchemaUref casper.Uref) (Schemas, error) {
-	schemasURefValue, err := casperClient.QueryGlobalStateByStateHash(context.Background(), &stateRootHash, eventSchemaUref.String(), nil)
+// LoadContractEventSchemas load schemas of the contract checking them against DefaultDecodeLimits
+func LoadContractEventSchemas(stateReader StateReader, stateRootHash string, eventSchemaUref casper.Uref) (Schemas, error) {
+	return loadContractEventSchemas(context.Background(), stateReader, stateRootHash, eventSchemaUref, DefaultDecodeLimits)
+}
+
+func loadContractEventSchemas(ctx context.Context, stateReader StateReader, stateRootHash string, eventSchemaUref casper.Uref, limits DecodeLimits) (Schemas, error) {
+	schemasURefValue, err := stateReader.QueryGlobalStateByStateHash(ctx, &stateRootHash, eventSchemaUref.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -282,5 +691,5 @@ func LoadContractEventSchemas(casperClient casper.RPCClient, stateRootHash strin
 	if err != nil {
 		return nil, err
 	}
-	return NewSchemasFromBytes(hexBytes)
+	return newSchemasFromBytes(hexBytes, limits)
 }
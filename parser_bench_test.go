@@ -0,0 +1,59 @@
+package ces
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/types"
+	"github.com/stretchr/testify/require"
+)
+
+// BenchmarkParseExecutionResults parse the execution result with two voting events of 5 and 12 fields
+func BenchmarkParseExecutionResults(b *testing.B) {
+	metadata := newTestContractMetadata(b, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	metadata.SetSchemas(metadata.Schemas)
+	eventParser := EventParser{
+		contractsMetadata: map[string]ContractMetadata{metadata.EventsURef.String(): metadata},
+	}
+
+	data, err := os.ReadFile("./utils/fixtures/deploys/voting_created.json")
+	require.NoError(b, err)
+
+	var results struct {
+		ExecutionResults []types.DeployExecutionResult `json:"execution_results"`
+	}
+	require.NoError(b, json.Unmarshal(data, &results))
+	executionResult := types.DeployExecutionInfoFromV1(results.ExecutionResults, nil).ExecutionResult
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parseResults, err := eventParser.ParseExecutionResults(executionResult)
+		if err != nil || len(parseResults) != 2 {
+			b.Fatal("unexpected parse results", err)
+		}
+	}
+}
+
+// BenchmarkParseEvent decode the single BallotCast event of 5 fields from the __events dictionary value
+func BenchmarkParseEvent(b *testing.B) {
+	metadata := newTestContractMetadata(b, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	metadata.SetSchemas(metadata.Schemas)
+
+	rawEvent, err := hex.DecodeString(ballotCastEventHex)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		eventMetadata, err := parseEventMetadataFromDictionaryBytes(rawEvent, DefaultDecodeLimits)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if result := parseEventWithMetadata(metadata, eventMetadata, DefaultDecodeLimits); result.Error != nil {
+			b.Fatal(result.Error)
+		}
+	}
+}
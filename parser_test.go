@@ -2,10 +2,15 @@ package ces
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/make-software/casper-go-sdk/v2/casper"
@@ -18,11 +23,26 @@ import (
 	"github.com/make-software/ces-go-parser/v2/utils/mocks"
 )
 
+var (
+	votingSchemaHex = readHexFixture("./utils/fixtures/events/voting_schema.hex")
+	// ballotCastEventHex is the __events dictionary value of BallotCast event with id 2
+	ballotCastEventHex = readHexFixture("./utils/fixtures/events/ballot_cast.hex")
+)
+
+// readHexFixture read hex encoded fixture shared between the package tests
+func readHexFixture(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
 func TestEventParser(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedClient := mocks.NewMockStateReader(mockCtrl)
 
 	contractHashToParse, err := casper.NewHash("ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
 	assert.NoError(t, err)
@@ -31,7 +51,7 @@ func TestEventParser(t *testing.T) {
 	assert.NoError(t, err)
 
 	eventParser := EventParser{
-		casperClient: mockedClient,
+		stateReader: mockedClient,
 	}
 
 	t.Run("Test several events parsing", func(t *testing.T) {
@@ -71,7 +91,7 @@ func TestEventParser(t *testing.T) {
 				},
 			}, nil)
 
-		contractsMetadata, err := eventParser.loadContractsMetadata([]casper.Hash{contractHashToParse})
+		contractsMetadata, _, err := eventParser.loadContractsMetadata(context.Background(), []casper.Hash{contractHashToParse})
 		require.NoError(t, err)
 
 		eventParser.contractsMetadata = contractsMetadata
@@ -109,6 +129,33 @@ func TestEventParser(t *testing.T) {
 		assert.Equal(t, parseResults[1].Event.EventID, uint(3))
 		assert.True(t, len(parseResults[1].Event.Data) > 0)
 	})
+
+	t.Run("Test events filtering", func(t *testing.T) {
+		data, err := os.ReadFile("./utils/fixtures/deploys/voting_created.json")
+		require.NoError(t, err)
+
+		var results struct {
+			ExecutionResults []types.DeployExecutionResult `json:"execution_results"`
+		}
+		require.NoError(t, json.Unmarshal(data, &results))
+		res := types.DeployExecutionInfoFromV1(results.ExecutionResults, nil)
+
+		eventParser.filter = &EventFilter{EventNames: []EventName{"SimpleVotingCreated"}}
+		defer func() { eventParser.filter = nil }()
+
+		parseResults, err := eventParser.ParseExecutionResults(res.ExecutionResult)
+		require.NoError(t, err)
+		require.Len(t, parseResults, 1)
+		assert.Equal(t, "SimpleVotingCreated", parseResults[0].Event.Name)
+
+		otherHash, err := casper.NewHash("0640eb43bd95d5c88b799862bc9fb42d7a241f1a8aae5deaa03170a27ee8eeaa")
+		require.NoError(t, err)
+		eventParser.filter = &EventFilter{ContractHashes: []casper.Hash{otherHash}}
+
+		parseResults, err = eventParser.ParseExecutionResults(res.ExecutionResult)
+		require.NoError(t, err)
+		assert.Len(t, parseResults, 0)
+	})
 }
 
 func TestParseEventAndData(t *testing.T) {
@@ -118,10 +165,10 @@ func TestParseEventAndData(t *testing.T) {
 	contractHashToParse, err := casper.NewHash("ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
 	assert.NoError(t, err)
 
-	mockedClient := mocks.NewMockClient(mockCtrl)
+	mockedClient := mocks.NewMockStateReader(mockCtrl)
 
 	eventParser := EventParser{
-		casperClient: mockedClient,
+		stateReader: mockedClient,
 	}
 
 	var schemaHex = `08000000100000004164646564546f57686974656c6973740100000007000000616464726573730b0e00000042616c6c6f7443616e63656c65640500000005000000766f7465720b09000000766f74696e675f6964040b000000766f74696e675f74797065030600000063686f69636503050000007374616b65080a00000042616c6c6f74436173740500000005000000766f7465720b09000000766f74696e675f6964040b000000766f74696e675f74797065030600000063686f69636503050000007374616b65080c0000004f776e65724368616e67656401000000090000006e65775f6f776e65720b1400000052656d6f76656446726f6d57686974656c6973740100000007000000616464726573730b1300000053696d706c65566f74696e67437265617465640c0000000d000000646f63756d656e745f686173680a0700000063726561746f720b050000007374616b650d0809000000766f74696e675f69640416000000636f6e6669675f696e666f726d616c5f71756f72756d041b000000636f6e6669675f696e666f726d616c5f766f74696e675f74696d650514000000636f6e6669675f666f726d616c5f71756f72756d0419000000636f6e6669675f666f726d616c5f766f74696e675f74696d650516000000636f6e6669675f746f74616c5f6f6e626f61726465640822000000636f6e6669675f646f75626c655f74696d655f6265747765656e5f766f74696e6773001d000000636f6e6669675f766f74696e675f636c6561726e6573735f64656c7461082e000000636f6e6669675f74696d655f6265747765656e5f696e666f726d616c5f616e645f666f726d616c5f766f74696e67050e000000566f74696e6743616e63656c65640300000009000000766f74696e675f6964040b000000766f74696e675f747970650308000000756e7374616b6573110b080b000000566f74696e67456e6465640d00000009000000766f74696e675f6964040b000000766f74696e675f74797065030d000000766f74696e675f726573756c74030e0000007374616b655f696e5f6661766f72080d0000007374616b655f616761696e73740816000000756e626f756e645f7374616b655f696e5f6661766f720815000000756e626f756e645f7374616b655f616761696e7374080e000000766f7465735f696e5f6661766f72040d000000766f7465735f616761696e73740408000000756e7374616b657311130b0408060000007374616b657311130b0408050000006275726e7311130b0408050000006d696e747311130b0408`
@@ -150,3 +197,202 @@ func TestParseEventAndData(t *testing.T) {
 	assert.Equal(t, eventName, "BallotCast")
 	assert.True(t, len(eventData) > 0)
 }
+
+func TestFetchEvent(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockedClient := mocks.NewMockStateReader(mockCtrl)
+
+	contractHash, err := casper.NewHash("ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	require.NoError(t, err)
+
+	eventsURef, err := casper.NewUref("uref-d2263e86f497f42e405d5d1390aa3c1a8bfc35f3699fdc3be806a5cfe139dac9-007")
+	require.NoError(t, err)
+
+	schemaBytes, err := hex.DecodeString(votingSchemaHex)
+	require.NoError(t, err)
+
+	schemas, err := NewSchemasFromBytes(schemaBytes)
+	require.NoError(t, err)
+
+	eventParser := EventParser{
+		stateReader: mockedClient,
+		contractsMetadata: map[string]ContractMetadata{
+			eventsURef.String(): {
+				Schemas:      schemas,
+				ContractHash: contractHash,
+				EventsURef:   eventsURef,
+			},
+		},
+	}
+
+	var arg casper.Argument
+	err = json.Unmarshal([]byte(fmt.Sprintf(`{"cl_type": "Any", "bytes": "%s"}`, ballotCastEventHex)), &arg)
+	require.NoError(t, err)
+
+	mockedClient.EXPECT().GetDictionaryItem(context.Background(), nil, eventsURef.String(), "2").Return(rpc.StateGetDictionaryResult{
+		StoredValue: casper.StoredValue{
+			CLValue: &arg,
+		},
+	}, nil)
+
+	result, err := eventParser.FetchEvent(context.Background(), contractHash, 2)
+	require.NoError(t, err)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, "BallotCast", result.Event.Name)
+	assert.Equal(t, uint(2), result.Event.EventID)
+	assert.True(t, len(result.Event.Data) > 0)
+
+	_, err = eventParser.FetchEvent(context.Background(), casper.Hash{}, 2)
+	assert.ErrorIs(t, err, ErrContractNotObserved)
+
+	_, err = eventParser.FetchEvents(context.Background(), contractHash, 3, 2)
+	assert.ErrorIs(t, err, ErrInvalidEventIDRange)
+
+	_, err = eventParser.FetchEvents(context.Background(), contractHash, 0, ^uint(0))
+	assert.ErrorIs(t, err, ErrEventIDRangeTooLarge)
+
+	maxEventID := strconv.FormatUint(uint64(^uint(0)), 10)
+	mockedClient.EXPECT().GetDictionaryItem(context.Background(), nil, eventsURef.String(), maxEventID).Return(rpc.StateGetDictionaryResult{
+		StoredValue: casper.StoredValue{
+			CLValue: &arg,
+		},
+	}, nil)
+
+	results, err := eventParser.FetchEvents(context.Background(), contractHash, ^uint(0), ^uint(0))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "BallotCast", results[0].Event.Name)
+}
+
+func TestLoadContractsMetadataParallel(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockedClient := mocks.NewMockStateReader(mockCtrl)
+
+	var schemaArg casper.Argument
+	err := json.Unmarshal([]byte(fmt.Sprintf(`{"cl_type": "Any", "bytes": "%s"}`, votingSchemaHex)), &schemaArg)
+	require.NoError(t, err)
+
+	contractHashes := make([]casper.Hash, 8)
+	for i := range contractHashes {
+		contractHashes[i], err = casper.NewHash(fmt.Sprintf("%064x", i+1))
+		require.NoError(t, err)
+	}
+
+	// contracts with failedIdxs return errors, the error of the first one in contractHashes order is expected
+	newClient := func(failedIdxs ...int) {
+		stateRootHash, _ := casper.NewHash("002596e815c7235dccf76358695de0088b4636ecb2473c12bb5ff0fbbb7ae94a")
+		mockedClient.EXPECT().GetStateRootHashLatest(gomock.Any()).Return(casper.ChainGetStateRootHashResult{StateRootHash: stateRootHash}, nil)
+		mockedClient.EXPECT().QueryGlobalStateByStateHash(gomock.Any(), gomock.Any(), gomock.Any(), nil).DoAndReturn(
+			func(_ context.Context, _ *string, queryKey string, _ []string) (rpc.QueryGlobalStateResult, error) {
+				if strings.HasPrefix(queryKey, "uref-") {
+					return rpc.QueryGlobalStateResult{StoredValue: casper.StoredValue{CLValue: &schemaArg}}, nil
+				}
+
+				for i, hash := range contractHashes {
+					if queryKey != fmt.Sprintf("hash-%s", hash.ToHex()) {
+						continue
+					}
+					for _, failedIdx := range failedIdxs {
+						if i == failedIdx {
+							return rpc.QueryGlobalStateResult{}, fmt.Errorf("contract %d failed", i)
+						}
+					}
+
+					eventsURef, _ := key.NewKey(fmt.Sprintf("uref-%064x-007", i+1))
+					eventsSchemaURef, _ := key.NewKey(fmt.Sprintf("uref-%064x-007", i+100))
+					return rpc.QueryGlobalStateResult{StoredValue: casper.StoredValue{Contract: &casper.Contract{
+						NamedKeys: casper.NamedKeys{
+							{Name: eventNamedKey, Key: eventsURef},
+							{Name: eventSchemaNamedKey, Key: eventsSchemaURef},
+						},
+					}}}, nil
+				}
+				return rpc.QueryGlobalStateResult{}, fmt.Errorf("unexpected key %s", queryKey)
+			}).AnyTimes()
+	}
+
+	t.Run("All contracts loaded", func(t *testing.T) {
+		newClient()
+
+		parser, err := NewParser(mockedClient, contractHashes, WithLoadWorkers(4), WithLoadRateLimit(10000))
+		require.NoError(t, err)
+		require.Len(t, parser.contractsMetadata, len(contractHashes))
+
+		for _, hash := range contractHashes {
+			metadata, err := parser.contractMetadataByHash(hash)
+			require.NoError(t, err)
+			assert.Len(t, metadata.Schemas, 8)
+		}
+	})
+
+	t.Run("First failed contract is reported", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			mockCtrl := gomock.NewController(t)
+			mockedClient = mocks.NewMockStateReader(mockCtrl)
+			newClient(6, 2, 5)
+
+			parser, err := NewParser(mockedClient, contractHashes, WithLoadWorkers(4))
+			assert.Nil(t, parser)
+			assert.EqualError(t, err, "contract 2 failed")
+		}
+	})
+
+	t.Run("All requests are rate limited", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedClient = mocks.NewMockStateReader(mockCtrl)
+		newClient()
+
+		// the state root hash, contract and schema requests wait for a tick each
+		startedAt := time.Now()
+		_, err := NewParser(mockedClient, contractHashes[:1], WithLoadRateLimit(20))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(startedAt), 3*50*time.Millisecond)
+	})
+
+	t.Run("Partial load", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedClient = mocks.NewMockStateReader(mockCtrl)
+		newClient(6, 2)
+
+		parser, err := NewParser(mockedClient, contractHashes, WithLoadWorkers(4), WithPartialLoad())
+		require.NoError(t, err)
+		assert.Len(t, parser.contractsMetadata, len(contractHashes)-2)
+
+		failed := parser.FailedContracts()
+		require.Len(t, failed, 2)
+		assert.Equal(t, contractHashes[2], failed[0].ContractHash)
+		assert.EqualError(t, failed[0].Err, "contract 2 failed")
+		assert.Equal(t, contractHashes[6], failed[1].ContractHash)
+
+		_, err = parser.contractMetadataByHash(contractHashes[2])
+		assert.ErrorIs(t, err, ErrContractNotObserved)
+	})
+
+	t.Run("Partial load with canceled context", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedClient = mocks.NewMockStateReader(mockCtrl)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// no RPC calls are expected, the context is canceled before the first one
+		parser, err := NewParserContext(ctx, mockedClient, contractHashes, WithLoadWorkers(4), WithPartialLoad())
+		assert.Nil(t, parser)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Partial load with failed state root hash", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		mockedClient = mocks.NewMockStateReader(mockCtrl)
+		rpcErr := errors.New("node is unavailable")
+		mockedClient.EXPECT().GetStateRootHashLatest(gomock.Any()).Return(casper.ChainGetStateRootHashResult{}, rpcErr)
+
+		parser, err := NewParser(mockedClient, contractHashes, WithLoadWorkers(4), WithPartialLoad())
+		assert.Nil(t, parser)
+		assert.ErrorIs(t, err, rpcErr)
+	})
+}
@@ -0,0 +1,108 @@
+// Package promobserver exposes ces.Observer callbacks as Prometheus metrics. It is a separate module to keep
+// the Prometheus client out of the parser dependencies.
+package promobserver
+
+import (
+	"time"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/make-software/ces-go-parser/v2"
+)
+
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+	// unknownEventName replace names of events missing in the contract schema, they come from the chain
+	// and would make the event_name label unbounded
+	unknownEventName = "unknown"
+)
+
+// Observer implements ces.Observer and prometheus.Collector, register it with the Prometheus registry
+// and pass it to the parser with ces.WithObserver
+type Observer struct {
+	eventsParsed      *prometheus.CounterVec
+	eventsFailed      *prometheus.CounterVec
+	transformsSkipped *prometheus.CounterVec
+	schemasLoaded     *prometheus.CounterVec
+	rpcDuration       *prometheus.HistogramVec
+}
+
+// New create Observer with metrics prefixed by the namespace, ces_events_parsed_total for "ces" namespace
+func New(namespace string) *Observer {
+	return &Observer{
+		eventsParsed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "events_parsed_total",
+			Help:      "Number of decoded CES events.",
+		}, []string{"contract_hash", "event_name"}),
+		eventsFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "events_failed_total",
+			Help:      "Number of CES events of observed contracts failed to decode.",
+		}, []string{"contract_hash", "event_name", "error_class"}),
+		transformsSkipped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transforms_skipped_total",
+			Help:      "Number of WriteCLValue transforms skipped by the parser.",
+		}, []string{"reason"}),
+		schemasLoaded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "schemas_loaded_total",
+			Help:      "Number of contract schema loads.",
+		}, []string{"contract_hash", "result"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rpc_duration_seconds",
+			Help:      "Latency of RPC calls made by the parser.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "result"}),
+	}
+}
+
+func (o *Observer) EventParsed(event ces.Event) {
+	o.eventsParsed.WithLabelValues(event.ContractHash.ToHex(), event.Name).Inc()
+}
+
+func (o *Observer) EventFailed(contractHash casper.Hash, eventName string, class ces.ErrorClass, _ error) {
+	if class == ces.ErrorClassUnknownEvent {
+		eventName = unknownEventName
+	}
+	o.eventsFailed.WithLabelValues(contractHash.ToHex(), eventName, string(class)).Inc()
+}
+
+func (o *Observer) TransformSkipped(_ uint, reason ces.SkipReason) {
+	o.transformsSkipped.WithLabelValues(string(reason)).Inc()
+}
+
+func (o *Observer) SchemaLoaded(contractHash casper.Hash, err error) {
+	o.schemasLoaded.WithLabelValues(contractHash.ToHex(), result(err)).Inc()
+}
+
+func (o *Observer) RPCCompleted(method string, duration time.Duration, err error) {
+	o.rpcDuration.WithLabelValues(method, result(err)).Observe(duration.Seconds())
+}
+
+func (o *Observer) Describe(ch chan<- *prometheus.Desc) {
+	o.eventsParsed.Describe(ch)
+	o.eventsFailed.Describe(ch)
+	o.transformsSkipped.Describe(ch)
+	o.schemasLoaded.Describe(ch)
+	o.rpcDuration.Describe(ch)
+}
+
+func (o *Observer) Collect(ch chan<- prometheus.Metric) {
+	o.eventsParsed.Collect(ch)
+	o.eventsFailed.Collect(ch)
+	o.transformsSkipped.Collect(ch)
+	o.schemasLoaded.Collect(ch)
+	o.rpcDuration.Collect(ch)
+}
+
+func result(err error) string {
+	if err != nil {
+		return resultError
+	}
+	return resultSuccess
+}
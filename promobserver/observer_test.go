@@ -0,0 +1,58 @@
+package promobserver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2"
+)
+
+func TestObserver(t *testing.T) {
+	contractHash, err := casper.NewHash("ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	require.NoError(t, err)
+
+	observer := New("ces")
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(observer))
+
+	var _ ces.Observer = observer
+	observer.EventParsed(ces.Event{ContractHash: contractHash, Name: "Transfer"})
+	observer.EventParsed(ces.Event{ContractHash: contractHash, Name: "Transfer"})
+	observer.EventFailed(contractHash, "Mint", ces.ErrorClassUnknownEvent, ces.ErrEventNameNotInSchema)
+	observer.TransformSkipped(3, ces.SkipReasonNotEvent)
+	observer.SchemaLoaded(contractHash, nil)
+	observer.RPCCompleted("GetDictionaryItem", 20*time.Millisecond, errors.New("timeout"))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(observer.eventsParsed.WithLabelValues(contractHash.ToHex(), "Transfer")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(observer.eventsFailed.WithLabelValues(contractHash.ToHex(), "unknown", "unknown_event")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(observer.transformsSkipped.WithLabelValues("not_event")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(observer.schemasLoaded.WithLabelValues(contractHash.ToHex(), "success")))
+
+	expected := `
+# HELP ces_rpc_duration_seconds Latency of RPC calls made by the parser.
+# TYPE ces_rpc_duration_seconds histogram
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="0.005"} 0
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="0.01"} 0
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="0.025"} 1
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="0.05"} 1
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="0.1"} 1
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="0.25"} 1
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="0.5"} 1
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="1"} 1
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="2.5"} 1
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="5"} 1
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="10"} 1
+ces_rpc_duration_seconds_bucket{method="GetDictionaryItem",result="error",le="+Inf"} 1
+ces_rpc_duration_seconds_sum{method="GetDictionaryItem",result="error"} 0.02
+ces_rpc_duration_seconds_count{method="GetDictionaryItem",result="error"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "ces_rpc_duration_seconds"))
+}
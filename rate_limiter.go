@@ -0,0 +1,45 @@
+package ces
+
+import (
+	"context"
+	"time"
+)
+
+// rateLimiter spaces out RPC requests shared by several goroutines, nil rateLimiter does not limit
+type rateLimiter struct {
+	ticker *time.Ticker
+}
+
+func newRateLimiter(requestsPerSecond int) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	interval := time.Second / time.Duration(requestsPerSecond)
+	if interval <= 0 {
+		return nil
+	}
+
+	return &rateLimiter{ticker: time.NewTicker(interval)}
+}
+
+// wait for the next tick, the context error is returned if it is done first
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.ticker.C:
+		return nil
+	}
+}
+
+func (l *rateLimiter) stop() {
+	if l == nil {
+		return
+	}
+	l.ticker.Stop()
+}
@@ -0,0 +1,154 @@
+package ces
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+)
+
+type (
+	// SchemaRegistry aggregates schemas of many contracts and answers cross-contract event signature queries
+	SchemaRegistry struct {
+		mu        sync.RWMutex
+		contracts map[casper.Hash]ContractMetadata
+	}
+
+	// EventVariant is a distinct shape of the event name shared by one or more contracts
+	EventVariant struct {
+		Name           EventName
+		Fields         []SchemaData
+		Fingerprint    SchemaFingerprint
+		ContractHashes []casper.Hash
+	}
+)
+
+func NewSchemaRegistry(contractsMetadata ...ContractMetadata) *SchemaRegistry {
+	registry := &SchemaRegistry{
+		contracts: make(map[casper.Hash]ContractMetadata, len(contractsMetadata)),
+	}
+
+	for _, metadata := range contractsMetadata {
+		registry.Add(metadata)
+	}
+
+	return registry
+}
+
+// SchemaRegistry return registry built from the metadata of all observed contracts
+func (p *EventParser) SchemaRegistry() *SchemaRegistry {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	registry := NewSchemaRegistry()
+	for _, metadata := range p.contractsMetadata {
+		registry.Add(metadata)
+	}
+
+	return registry
+}
+
+// Add put contract metadata into the registry replacing the previous one of the same contract
+func (r *SchemaRegistry) Add(metadata ContractMetadata) {
+	if metadata.EventFingerprints == nil {
+		metadata.SetSchemas(metadata.Schemas)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.contracts[metadata.ContractHash] = metadata
+}
+
+// Remove delete contract from the registry
+func (r *SchemaRegistry) Remove(contractHash casper.Hash) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.contracts, contractHash)
+}
+
+// ContractsWithEvent return hashes of contracts emitting the event with provided name and exactly the provided
+// ordered fields, any fields match if fields is empty
+func (r *SchemaRegistry) ContractsWithEvent(name EventName, fields []SchemaData) []casper.Hash {
+	if len(fields) == 0 {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		var result []casper.Hash
+		for hash, metadata := range r.contracts {
+			if _, ok := metadata.Schemas[name]; ok {
+				result = append(result, hash)
+			}
+		}
+		return sortHashes(result)
+	}
+
+	return r.ContractsWithSignature(EventSignatureFingerprint(name, fields))
+}
+
+// ContractsWithSignature return hashes of contracts emitting the event with provided signature fingerprint
+func (r *SchemaRegistry) ContractsWithSignature(fingerprint SchemaFingerprint) []casper.Hash {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []casper.Hash
+	for hash, metadata := range r.contracts {
+		for _, eventFingerprint := range metadata.EventFingerprints {
+			if eventFingerprint == fingerprint {
+				result = append(result, hash)
+				break
+			}
+		}
+	}
+
+	return sortHashes(result)
+}
+
+// EventVariants return distinct shapes of the event name across all contracts ordered by the number of contracts
+func (r *SchemaRegistry) EventVariants(name EventName) []EventVariant {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	variants := make(map[SchemaFingerprint]*EventVariant)
+	for hash, metadata := range r.contracts {
+		fields, ok := metadata.Schemas[name]
+		if !ok {
+			continue
+		}
+
+		fingerprint := metadata.EventFingerprints[name]
+		variant, ok := variants[fingerprint]
+		if !ok {
+			variant = &EventVariant{
+				Name:        name,
+				Fields:      fields,
+				Fingerprint: fingerprint,
+			}
+			variants[fingerprint] = variant
+		}
+		variant.ContractHashes = append(variant.ContractHashes, hash)
+	}
+
+	result := make([]EventVariant, 0, len(variants))
+	for _, variant := range variants {
+		variant.ContractHashes = sortHashes(variant.ContractHashes)
+		result = append(result, *variant)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].ContractHashes) != len(result[j].ContractHashes) {
+			return len(result[i].ContractHashes) > len(result[j].ContractHashes)
+		}
+		return result[i].Fingerprint.String() < result[j].Fingerprint.String()
+	})
+
+	return result
+}
+
+func sortHashes(hashes []casper.Hash) []casper.Hash {
+	sort.Slice(hashes, func(i, j int) bool {
+		return hashes[i].ToHex() < hashes[j].ToHex()
+	})
+	return hashes
+}
@@ -0,0 +1,44 @@
+package ces
+
+import (
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRegistry(t *testing.T) {
+	transfer := []SchemaData{
+		{ParamName: "from", ParamType: cltype.Key},
+		{ParamName: "to", ParamType: cltype.Key},
+		{ParamName: "amount", ParamType: cltype.UInt256},
+	}
+
+	newMetadata := func(hashHex string, schemas Schemas) ContractMetadata {
+		hash, err := casper.NewHash(hashHex)
+		require.NoError(t, err)
+		return ContractMetadata{ContractHash: hash, Schemas: schemas}
+	}
+
+	first := newMetadata("0640eb43bd95d5c88b799862bc9fb42d7a241f1a8aae5deaa03170a27ee8eeaa", Schemas{"Transfer": transfer})
+	second := newMetadata("e7062b42c9a22002fa3cd216debd605b7056ad180efb3c99555676f1a1e801e5", Schemas{"Transfer": transfer, "Burn": transfer[:1]})
+	third := newMetadata("ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc", Schemas{"Transfer": transfer[:2]})
+
+	registry := NewSchemaRegistry(first, second, third)
+
+	assert.Equal(t, []casper.Hash{first.ContractHash, second.ContractHash}, registry.ContractsWithEvent("Transfer", transfer))
+	assert.Equal(t, []casper.Hash{first.ContractHash, second.ContractHash, third.ContractHash}, registry.ContractsWithEvent("Transfer", nil))
+	assert.Equal(t, []casper.Hash{second.ContractHash}, registry.ContractsWithEvent("Burn", nil))
+	assert.Empty(t, registry.ContractsWithEvent("Mint", nil))
+
+	variants := registry.EventVariants("Transfer")
+	require.Len(t, variants, 2)
+	assert.Len(t, variants[0].ContractHashes, 2)
+	assert.Len(t, variants[0].Fields, 3)
+	assert.Equal(t, []casper.Hash{third.ContractHash}, variants[1].ContractHashes)
+
+	registry.Remove(first.ContractHash)
+	assert.Equal(t, []casper.Hash{second.ContractHash}, registry.ContractsWithEvent("Transfer", transfer))
+}
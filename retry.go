@@ -0,0 +1,161 @@
+package ces
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/make-software/casper-go-sdk/v2/rpc"
+)
+
+const (
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+	defaultRetryMultiplier     = 2
+)
+
+type (
+	// RetryPolicy configures retries of the RPC calls EventParser makes through StateReader
+	RetryPolicy struct {
+		// MaxAttempts is the total number of attempts including the first one, values below 2 disable retries
+		MaxAttempts int
+		// InitialBackoff is the delay before the first retry, 100ms by default
+		InitialBackoff time.Duration
+		// MaxBackoff caps the delay between attempts, 5s by default
+		MaxBackoff time.Duration
+		// Multiplier grows the delay after every attempt, 2 by default
+		Multiplier float64
+		// Jitter is the fraction of the delay randomly subtracted from it, in range [0, 1]
+		Jitter float64
+		// Retryable classifies errors worth retrying, DefaultRetryable is used if nil
+		Retryable func(err error) bool
+		// OnRetry is called before waiting for the next attempt
+		OnRetry func(attempt RetryAttempt)
+		// OnGiveUp is called when the call fails and no more attempts are made
+		OnGiveUp func(attempt RetryAttempt)
+	}
+
+	RetryAttempt struct {
+		// Method is the name of the StateReader method
+		Method string
+		// Attempt is the number of the failed attempt starting from 1
+		Attempt int
+		Err     error
+		// Backoff is the delay before the next attempt, zero for OnGiveUp
+		Backoff time.Duration
+	}
+
+	// retryingStateReader wraps RPC methods used by the library with the retry policy
+	retryingStateReader struct {
+		StateReader
+		policy RetryPolicy
+	}
+)
+
+// DefaultRetryable retry network errors, broken connections, HTTP 5xx and 429 responses,
+// context cancellation is never retried
+func DefaultRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr *rpc.HttpError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func newRetryingStateReader(reader StateReader, policy RetryPolicy) StateReader {
+	if policy.MaxAttempts < 2 {
+		return reader
+	}
+
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = defaultRetryInitialBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaultRetryMaxBackoff
+	}
+	if policy.Multiplier < 1 {
+		policy.Multiplier = defaultRetryMultiplier
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = DefaultRetryable
+	}
+
+	return &retryingStateReader{StateReader: reader, policy: policy}
+}
+
+func (c *retryingStateReader) GetStateRootHashLatest(ctx context.Context) (rpc.ChainGetStateRootHashResult, error) {
+	return retryCall(ctx, c.policy, "GetStateRootHashLatest", func() (rpc.ChainGetStateRootHashResult, error) {
+		return c.StateReader.GetStateRootHashLatest(ctx)
+	})
+}
+
+func (c *retryingStateReader) QueryGlobalStateByStateHash(ctx context.Context, stateRootHash *string, key string, path []string) (rpc.QueryGlobalStateResult, error) {
+	return retryCall(ctx, c.policy, "QueryGlobalStateByStateHash", func() (rpc.QueryGlobalStateResult, error) {
+		return c.StateReader.QueryGlobalStateByStateHash(ctx, stateRootHash, key, path)
+	})
+}
+
+func (c *retryingStateReader) GetDictionaryItem(ctx context.Context, stateRootHash *string, uref, key string) (rpc.StateGetDictionaryResult, error) {
+	return retryCall(ctx, c.policy, "GetDictionaryItem", func() (rpc.StateGetDictionaryResult, error) {
+		return c.StateReader.GetDictionaryItem(ctx, stateRootHash, uref, key)
+	})
+}
+
+func retryCall[T any](ctx context.Context, policy RetryPolicy, method string, call func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := call()
+		if err == nil {
+			return result, nil
+		}
+
+		if attempt >= policy.MaxAttempts || !policy.Retryable(err) {
+			if policy.OnGiveUp != nil {
+				policy.OnGiveUp(RetryAttempt{Method: method, Attempt: attempt, Err: err})
+			}
+			return result, err
+		}
+
+		backoff := policy.backoff(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(RetryAttempt{Method: method, Attempt: attempt, Err: err, Backoff: backoff})
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff return the delay after the failed attempt with the applied jitter
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	jitter := math.Min(math.Max(p.Jitter, 0), 1)
+	backoff -= backoff * jitter * rand.Float64()
+
+	return time.Duration(backoff)
+}
@@ -0,0 +1,138 @@
+package ces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2/utils/mocks"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	transientErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	stateRootHash, err := casper.NewHash("002596e815c7235dccf76358695de0088b4636ecb2473c12bb5ff0fbbb7ae94a")
+	require.NoError(t, err)
+
+	t.Run("Transient errors are retried", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockedClient := mocks.NewMockStateReader(mockCtrl)
+		gomock.InOrder(
+			mockedClient.EXPECT().GetStateRootHashLatest(gomock.Any()).Return(rpc.ChainGetStateRootHashResult{}, transientErr).Times(2),
+			mockedClient.EXPECT().GetStateRootHashLatest(gomock.Any()).Return(rpc.ChainGetStateRootHashResult{StateRootHash: stateRootHash}, nil),
+		)
+
+		var retries []RetryAttempt
+		client := newRetryingStateReader(mockedClient, RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			Jitter:         0.5,
+			OnRetry: func(attempt RetryAttempt) {
+				retries = append(retries, attempt)
+			},
+		})
+
+		result, err := client.GetStateRootHashLatest(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, stateRootHash, result.StateRootHash)
+
+		require.Len(t, retries, 2)
+		assert.Equal(t, "GetStateRootHashLatest", retries[0].Method)
+		assert.Equal(t, 1, retries[0].Attempt)
+		assert.Equal(t, 2, retries[1].Attempt)
+		assert.ErrorIs(t, retries[1].Err, transientErr)
+		assert.LessOrEqual(t, retries[1].Backoff, 2*time.Millisecond)
+	})
+
+	t.Run("Give up", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockedClient := mocks.NewMockStateReader(mockCtrl)
+		permanentErr := errors.New("dictionary item not found")
+		mockedClient.EXPECT().GetDictionaryItem(gomock.Any(), nil, "uref", "1").Return(rpc.StateGetDictionaryResult{}, permanentErr)
+		mockedClient.EXPECT().QueryGlobalStateByStateHash(gomock.Any(), nil, "hash", nil).Return(rpc.QueryGlobalStateResult{}, transientErr).Times(2)
+
+		var givenUp []RetryAttempt
+		client := newRetryingStateReader(mockedClient, RetryPolicy{
+			MaxAttempts:    2,
+			InitialBackoff: time.Millisecond,
+			OnGiveUp: func(attempt RetryAttempt) {
+				givenUp = append(givenUp, attempt)
+			},
+		})
+
+		_, err := client.GetDictionaryItem(context.Background(), nil, "uref", "1")
+		assert.ErrorIs(t, err, permanentErr)
+
+		_, err = client.QueryGlobalStateByStateHash(context.Background(), nil, "hash", nil)
+		assert.ErrorIs(t, err, transientErr)
+
+		require.Len(t, givenUp, 2)
+		assert.Equal(t, 1, givenUp[0].Attempt)
+		assert.Equal(t, 2, givenUp[1].Attempt)
+	})
+
+	t.Run("Canceled context stops retries", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		mockedClient := mocks.NewMockStateReader(mockCtrl)
+		mockedClient.EXPECT().GetStateRootHashLatest(ctx).DoAndReturn(func(context.Context) (rpc.ChainGetStateRootHashResult, error) {
+			cancel()
+			return rpc.ChainGetStateRootHashResult{}, transientErr
+		})
+
+		client := newRetryingStateReader(mockedClient, RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour})
+		_, err := client.GetStateRootHashLatest(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Parser option", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockedClient := mocks.NewMockStateReader(mockCtrl)
+		mockedClient.EXPECT().GetStateRootHashLatest(gomock.Any()).Return(rpc.ChainGetStateRootHashResult{}, transientErr).Times(3)
+
+		_, err := NewParser(mockedClient, []casper.Hash{stateRootHash}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+		assert.ErrorIs(t, err, transientErr)
+	})
+
+	t.Run("Parser loading is canceled", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		mockedClient := mocks.NewMockStateReader(mockCtrl)
+		mockedClient.EXPECT().GetStateRootHashLatest(ctx).DoAndReturn(func(context.Context) (rpc.ChainGetStateRootHashResult, error) {
+			cancel()
+			return rpc.ChainGetStateRootHashResult{}, &rpc.HttpError{SourceErr: errors.New("unavailable"), StatusCode: http.StatusServiceUnavailable}
+		})
+
+		parser, err := NewParserContext(ctx, mockedClient, []casper.Hash{stateRootHash}, WithRetryPolicy(RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour}))
+		assert.Nil(t, parser)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestDefaultRetryable(t *testing.T) {
+	assert.True(t, DefaultRetryable(&net.OpError{Op: "read", Err: errors.New("reset")}))
+	assert.False(t, DefaultRetryable(context.DeadlineExceeded))
+	assert.False(t, DefaultRetryable(ErrExpectContractStoredValue))
+	assert.True(t, DefaultRetryable(&rpc.HttpError{SourceErr: errors.New("bad gateway"), StatusCode: http.StatusBadGateway}))
+	assert.True(t, DefaultRetryable(fmt.Errorf("query: %w", &rpc.HttpError{SourceErr: errors.New("slow down"), StatusCode: http.StatusTooManyRequests})))
+	assert.False(t, DefaultRetryable(&rpc.HttpError{SourceErr: errors.New("not found"), StatusCode: http.StatusNotFound}))
+}
@@ -0,0 +1,157 @@
+package ces
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+)
+
+var (
+	ErrMissingEventsLengthNamedKey = errors.New("error: missing __events_length named key")
+	ErrExpectU32EventsLength       = errors.New("error: expect U32 events length")
+)
+
+const defaultScanWorkers = 8
+
+type (
+	// EventScanner iterates the whole event history of the observed contracts reading it directly from the global state
+	EventScanner struct {
+		parser  *EventParser
+		workers int
+	}
+
+	EventScannerOption func(*EventScanner)
+)
+
+// WithScanWorkers set the number of parallel dictionary reads
+func WithScanWorkers(workers int) EventScannerOption {
+	return func(s *EventScanner) {
+		if workers > 0 {
+			s.workers = workers
+		}
+	}
+}
+
+func NewEventScanner(parser *EventParser, opts ...EventScannerOption) *EventScanner {
+	scanner := &EventScanner{
+		parser:  parser,
+		workers: defaultScanWorkers,
+	}
+
+	for _, opt := range opts {
+		opt(scanner)
+	}
+
+	return scanner
+}
+
+// EventsLength read the number of events stored by the contract from the __events_length named key,
+// the latest state is used if stateRootHash is nil
+func (s *EventScanner) EventsLength(ctx context.Context, contractHash casper.Hash, stateRootHash *string) (uint, error) {
+	contractMetadata, err := s.parser.contractMetadataByHash(contractHash)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.eventsLength(ctx, contractMetadata, stateRootHash)
+}
+
+// Scan read all events stored by the contract at the provided state root hash, or at the latest one if nil,
+// and pass them to yield in the event id order. Scanning stops at the first error returned by yield.
+func (s *EventScanner) Scan(ctx context.Context, contractHash casper.Hash, stateRootHash *string, yield func(ParseResult) error) error {
+	contractMetadata, err := s.parser.contractMetadataByHash(contractHash)
+	if err != nil {
+		return err
+	}
+
+	// pin the state root hash to read all events from the same state
+	if stateRootHash == nil {
+		latest, err := s.parser.stateReader.GetStateRootHashLatest(ctx)
+		if err != nil {
+			return err
+		}
+		stateRoot := latest.StateRootHash.ToHex()
+		stateRootHash = &stateRoot
+	}
+
+	eventsLength, err := s.eventsLength(ctx, contractMetadata, stateRootHash)
+	if err != nil {
+		return err
+	}
+
+	batchSize := uint(s.workers)
+	for fromID := uint(0); fromID < eventsLength; fromID += batchSize {
+		toID := fromID + batchSize
+		if toID > eventsLength {
+			toID = eventsLength
+		}
+
+		results, err := s.fetchBatch(ctx, stateRootHash, contractMetadata, fromID, toID)
+		if err != nil {
+			return err
+		}
+
+		for _, result := range results {
+			if err = yield(result); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// fetchBatch read events with ids in range [fromID, toID) in parallel and return them in the id order
+func (s *EventScanner) fetchBatch(ctx context.Context, stateRootHash *string, contractMetadata ContractMetadata, fromID, toID uint) ([]ParseResult, error) {
+	var (
+		wg      sync.WaitGroup
+		results = make([]ParseResult, toID-fromID)
+		errs    = make([]error, toID-fromID)
+	)
+
+	for eventID := fromID; eventID < toID; eventID++ {
+		wg.Add(1)
+		go func(eventID uint) {
+			defer wg.Done()
+			results[eventID-fromID], errs[eventID-fromID] = s.parser.fetchEvent(ctx, stateRootHash, contractMetadata, eventID)
+		}(eventID)
+	}
+	wg.Wait()
+
+	// report the error of the lowest event id to keep the result deterministic
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+func (s *EventScanner) eventsLength(ctx context.Context, contractMetadata ContractMetadata, stateRootHash *string) (uint, error) {
+	if contractMetadata.EventsLengthURef == (casper.Uref{}) {
+		return 0, ErrMissingEventsLengthNamedKey
+	}
+
+	lengthResult, err := s.parser.stateReader.QueryGlobalStateByStateHash(ctx, stateRootHash, contractMetadata.EventsLengthURef.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if lengthResult.StoredValue.CLValue == nil {
+		return 0, ErrExpectCLValueStoredValue
+	}
+
+	value, err := lengthResult.StoredValue.CLValue.Value()
+	if err != nil {
+		return 0, err
+	}
+
+	if value.UI32 == nil {
+		return 0, ErrExpectU32EventsLength
+	}
+
+	return uint(value.UI32.Value()), nil
+}
@@ -0,0 +1,94 @@
+package ces
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2/utils/mocks"
+)
+
+func TestEventScanner(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockedClient := mocks.NewMockStateReader(mockCtrl)
+
+	contractHash, err := casper.NewHash("ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	require.NoError(t, err)
+
+	eventsURef, err := casper.NewUref("uref-d2263e86f497f42e405d5d1390aa3c1a8bfc35f3699fdc3be806a5cfe139dac9-007")
+	require.NoError(t, err)
+
+	eventsLengthURef, err := casper.NewUref("uref-32263e86f497f42e405d5d1390aa3c1a8bfc35f3699fdc3be806a5cfe139dac9-007")
+	require.NoError(t, err)
+
+	schemaBytes, err := hex.DecodeString(votingSchemaHex)
+	require.NoError(t, err)
+
+	schemas, err := NewSchemasFromBytes(schemaBytes)
+	require.NoError(t, err)
+
+	eventParser := &EventParser{
+		stateReader: mockedClient,
+		contractsMetadata: map[string]ContractMetadata{
+			eventsURef.String(): {
+				Schemas:          schemas,
+				ContractHash:     contractHash,
+				EventsURef:       eventsURef,
+				EventsLengthURef: eventsLengthURef,
+			},
+		},
+	}
+
+	stateRootHash, err := casper.NewHash("002596e815c7235dccf76358695de0088b4636ecb2473c12bb5ff0fbbb7ae94a")
+	require.NoError(t, err)
+	stateRoot := stateRootHash.ToHex()
+
+	var lengthArg casper.Argument
+	err = json.Unmarshal([]byte(`{"cl_type": "U32", "bytes": "03000000"}`), &lengthArg)
+	require.NoError(t, err)
+
+	mockedClient.EXPECT().GetStateRootHashLatest(gomock.Any()).Return(casper.ChainGetStateRootHashResult{StateRootHash: stateRootHash}, nil)
+	mockedClient.EXPECT().QueryGlobalStateByStateHash(gomock.Any(), &stateRoot, eventsLengthURef.String(), nil).Return(rpc.QueryGlobalStateResult{
+		StoredValue: casper.StoredValue{
+			CLValue: &lengthArg,
+		},
+	}, nil)
+	for _, eventID := range []string{"0", "1", "2"} {
+		// the dictionary item key is the last field of the payload, ballotCastEventHex is stored under "2"
+		eventHex := strings.TrimSuffix(ballotCastEventHex, hex.EncodeToString([]byte("2"))) + hex.EncodeToString([]byte(eventID))
+
+		var eventArg casper.Argument
+		err = json.Unmarshal([]byte(fmt.Sprintf(`{"cl_type": "Any", "bytes": "%s"}`, eventHex)), &eventArg)
+		require.NoError(t, err)
+
+		mockedClient.EXPECT().GetDictionaryItem(gomock.Any(), &stateRoot, eventsURef.String(), eventID).Return(rpc.StateGetDictionaryResult{
+			StoredValue: casper.StoredValue{
+				CLValue: &eventArg,
+			},
+		}, nil)
+	}
+
+	var results []ParseResult
+	err = NewEventScanner(eventParser, WithScanWorkers(2)).Scan(context.Background(), contractHash, nil, func(result ParseResult) error {
+		results = append(results, result)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for i, result := range results {
+		assert.NoError(t, result.Error)
+		assert.Equal(t, "BallotCast", result.Event.Name)
+		assert.Equal(t, uint(i), result.Event.EventID)
+	}
+}
@@ -20,7 +20,16 @@ type SchemaData struct {
 
 type Schemas map[EventName][]SchemaData
 
+// NewSchemasFromBytes decode __events_schema value checking it against DefaultDecodeLimits
 func NewSchemasFromBytes(rawSchemas []byte) (Schemas, error) {
+	return newSchemasFromBytes(rawSchemas, DefaultDecodeLimits)
+}
+
+func newSchemasFromBytes(rawSchemas []byte, limits DecodeLimits) (Schemas, error) {
+	if err := limits.checkSchemasBytes(rawSchemas); err != nil {
+		return nil, err
+	}
+
 	buf := bytes.NewBuffer(rawSchemas)
 	// For all the ces events schema for parsing CLType has next representation
 	cesEventCLTypeParsingSchema := cltype.Map{Key: cltype.String, Val: &cltype.List{ElementsType: &cltype.Tuple2{
@@ -0,0 +1,214 @@
+package ces
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+)
+
+type SchemaChangeKind int
+
+const (
+	EventAdded SchemaChangeKind = iota + 1
+	EventRemoved
+	FieldAdded
+	FieldRemoved
+	FieldReordered
+	FieldTypeChanged
+)
+
+func (k SchemaChangeKind) String() string {
+	switch k {
+	case EventAdded:
+		return "event_added"
+	case EventRemoved:
+		return "event_removed"
+	case FieldAdded:
+		return "field_added"
+	case FieldRemoved:
+		return "field_removed"
+	case FieldReordered:
+		return "field_reordered"
+	case FieldTypeChanged:
+		return "field_type_changed"
+	default:
+		return "unknown"
+	}
+}
+
+type (
+	// SchemaChange describes a single difference between two versions of contract schemas
+	SchemaChange struct {
+		Kind      SchemaChangeKind
+		EventName EventName
+		// FieldName is empty for the event level changes
+		FieldName string
+		// OldIndex and NewIndex are the field positions in the event, -1 if the field is absent in the version
+		OldIndex int
+		NewIndex int
+		// OldType and NewType are set for FieldTypeChanged
+		OldType cltype.CLType
+		NewType cltype.CLType
+		// Breaking is true if the change breaks existing decoders or consumers of the event
+		Breaking bool
+	}
+
+	// SchemaDiff is the list of changes between two versions of contract schemas
+	SchemaDiff struct {
+		Changes []SchemaChange
+	}
+)
+
+func (c SchemaChange) String() string {
+	compatibility := "compatible"
+	if c.Breaking {
+		compatibility = "breaking"
+	}
+
+	switch c.Kind {
+	case EventAdded, EventRemoved:
+		return fmt.Sprintf("%s: %s (%s)", c.Kind, c.EventName, compatibility)
+	case FieldTypeChanged:
+		return fmt.Sprintf("%s: %s.%s %s -> %s (%s)", c.Kind, c.EventName, c.FieldName, c.OldType, c.NewType, compatibility)
+	default:
+		return fmt.Sprintf("%s: %s.%s (%s)", c.Kind, c.EventName, c.FieldName, compatibility)
+	}
+}
+
+// IsEmpty return true if schemas are equal
+func (d SchemaDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// IsBreaking return true if any of the changes is breaking
+func (d SchemaDiff) IsBreaking() bool {
+	for _, change := range d.Changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffSchemas compare two versions of contract schemas and classify each change as compatible or breaking.
+//
+// Event payload is decoded positionally, so only adding new events and appending new fields
+// to the end of an event are compatible. Removing events or fields, inserting fields in the middle,
+// reordering fields and changing field CLType break existing decoders or consumers.
+func DiffSchemas(oldSchemas, newSchemas Schemas) SchemaDiff {
+	var diff SchemaDiff
+
+	for _, name := range sortedEventNames(oldSchemas, newSchemas) {
+		oldFields, inOld := oldSchemas[name]
+		newFields, inNew := newSchemas[name]
+
+		switch {
+		case !inOld:
+			diff.Changes = append(diff.Changes, SchemaChange{Kind: EventAdded, EventName: name, OldIndex: -1, NewIndex: -1})
+		case !inNew:
+			diff.Changes = append(diff.Changes, SchemaChange{Kind: EventRemoved, EventName: name, OldIndex: -1, NewIndex: -1, Breaking: true})
+		default:
+			diff.Changes = append(diff.Changes, diffEventFields(name, oldFields, newFields)...)
+		}
+	}
+
+	return diff
+}
+
+func diffEventFields(name EventName, oldFields, newFields []SchemaData) []SchemaChange {
+	var (
+		changes     []SchemaChange
+		oldIndexes  = fieldIndexes(oldFields)
+		newIndexes  = fieldIndexes(newFields)
+		lastCommon  = -1
+		commonOrder = make(map[string]int)
+	)
+
+	// position of the common fields relative to each other in the new version
+	for i, field := range newFields {
+		if _, ok := oldIndexes[field.ParamName]; ok && newIndexes[field.ParamName] == i {
+			commonOrder[field.ParamName] = len(commonOrder)
+			lastCommon = i
+		}
+	}
+
+	commonPosition := 0
+	for i, field := range oldFields {
+		if oldIndexes[field.ParamName] != i {
+			// duplicated field name, the first occurrence is compared
+			continue
+		}
+
+		newIndex, ok := newIndexes[field.ParamName]
+		if !ok {
+			changes = append(changes, SchemaChange{Kind: FieldRemoved, EventName: name, FieldName: field.ParamName, OldIndex: i, NewIndex: -1, Breaking: true})
+			continue
+		}
+
+		if commonOrder[field.ParamName] != commonPosition {
+			changes = append(changes, SchemaChange{Kind: FieldReordered, EventName: name, FieldName: field.ParamName, OldIndex: i, NewIndex: newIndex, Breaking: true})
+		}
+		commonPosition++
+
+		newType := newFields[newIndex].ParamType
+		if !bytes.Equal(field.ParamType.Bytes(), newType.Bytes()) {
+			changes = append(changes, SchemaChange{
+				Kind:      FieldTypeChanged,
+				EventName: name,
+				FieldName: field.ParamName,
+				OldIndex:  i,
+				NewIndex:  newIndex,
+				OldType:   field.ParamType,
+				NewType:   newType,
+				Breaking:  true,
+			})
+		}
+	}
+
+	for i, field := range newFields {
+		if _, ok := oldIndexes[field.ParamName]; ok || newIndexes[field.ParamName] != i {
+			continue
+		}
+
+		changes = append(changes, SchemaChange{
+			Kind:      FieldAdded,
+			EventName: name,
+			FieldName: field.ParamName,
+			OldIndex:  -1,
+			NewIndex:  i,
+			// field inserted before any of the existing fields shifts their positions in the payload
+			Breaking: i < lastCommon,
+		})
+	}
+
+	return changes
+}
+
+// fieldIndexes map field names to the position of their first occurrence
+func fieldIndexes(fields []SchemaData) map[string]int {
+	indexes := make(map[string]int, len(fields))
+	for i, field := range fields {
+		if _, ok := indexes[field.ParamName]; !ok {
+			indexes[field.ParamName] = i
+		}
+	}
+	return indexes
+}
+
+func sortedEventNames(schemas ...Schemas) []EventName {
+	unique := make(map[EventName]struct{})
+	for _, one := range schemas {
+		for name := range one {
+			unique[name] = struct{}{}
+		}
+	}
+
+	names := make([]EventName, 0, len(unique))
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
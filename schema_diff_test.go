@@ -0,0 +1,75 @@
+package ces
+
+import (
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	old := Schemas{
+		"Transfer": {
+			{ParamName: "from", ParamType: cltype.Key},
+			{ParamName: "to", ParamType: cltype.Key},
+			{ParamName: "amount", ParamType: cltype.UInt256},
+		},
+		"Burn": {
+			{ParamName: "owner", ParamType: cltype.Key},
+			{ParamName: "amount", ParamType: cltype.UInt256},
+		},
+	}
+
+	t.Run("Test equal schemas", func(t *testing.T) {
+		diff := DiffSchemas(old, old)
+		assert.True(t, diff.IsEmpty())
+		assert.False(t, diff.IsBreaking())
+	})
+
+	t.Run("Test compatible changes", func(t *testing.T) {
+		diff := DiffSchemas(old, Schemas{
+			"Transfer": {
+				{ParamName: "from", ParamType: cltype.Key},
+				{ParamName: "to", ParamType: cltype.Key},
+				{ParamName: "amount", ParamType: cltype.UInt256},
+				{ParamName: "memo", ParamType: &cltype.Option{Inner: cltype.String}},
+			},
+			"Burn": old["Burn"],
+			"Mint": {
+				{ParamName: "recipient", ParamType: cltype.Key},
+			},
+		})
+
+		require.Len(t, diff.Changes, 2)
+		assert.Equal(t, EventAdded, diff.Changes[0].Kind)
+		assert.Equal(t, "Mint", diff.Changes[0].EventName)
+		assert.Equal(t, FieldAdded, diff.Changes[1].Kind)
+		assert.Equal(t, "memo", diff.Changes[1].FieldName)
+		assert.False(t, diff.IsBreaking())
+	})
+
+	t.Run("Test breaking changes", func(t *testing.T) {
+		diff := DiffSchemas(old, Schemas{
+			"Transfer": {
+				{ParamName: "to", ParamType: cltype.Key},
+				{ParamName: "sender", ParamType: cltype.Key},
+				{ParamName: "from", ParamType: cltype.Key},
+				{ParamName: "amount", ParamType: cltype.UInt512},
+			},
+		})
+
+		assert.True(t, diff.IsBreaking())
+
+		kinds := make(map[SchemaChangeKind][]string)
+		for _, change := range diff.Changes {
+			assert.True(t, change.Breaking, change.String())
+			kinds[change.Kind] = append(kinds[change.Kind], change.EventName+"."+change.FieldName)
+		}
+
+		assert.Equal(t, []string{"Burn."}, kinds[EventRemoved])
+		assert.Equal(t, []string{"Transfer.from", "Transfer.to"}, kinds[FieldReordered])
+		assert.Equal(t, []string{"Transfer.amount"}, kinds[FieldTypeChanged])
+		assert.Equal(t, []string{"Transfer.sender"}, kinds[FieldAdded])
+	})
+}
@@ -0,0 +1,109 @@
+package ces
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+)
+
+type (
+	// SchemaChangedNotification is emitted when the execution result rewrites __events_schema of an observed contract
+	SchemaChangedNotification struct {
+		ContractHash casper.Hash
+		TransformID  uint
+		OldSchemas   Schemas
+		// NewSchemas is nil if the new schema failed to decode or was refused by the validation, the old one is kept in that case
+		NewSchemas Schemas
+		Diff       SchemaDiff
+		// Error is set if the new schema failed to decode, to pass the validation or to be put into the schema store
+		Error error
+	}
+
+	SchemaChangedHandler func(SchemaChangedNotification)
+)
+
+// refreshContractSchemas swap schemas of the observed contract if the transform rewrites its __events_schema URef.
+// The decoding and swap happen under the single write lock, so concurrent rewrites of the same contract
+// are applied one by one in the order they are seen and never overwrite each other with the metadata read before.
+// Handlers, the observer and the schema store are called after the lock is released.
+func (p *EventParser) refreshContractSchemas(transform casper.Transform, transformID uint) {
+	notification, contractMetadata, ok := p.swapContractSchemas(transform, transformID)
+	if !ok {
+		return
+	}
+
+	p.contractSchemasLoaded(contractMetadata.ContractHash, notification.NewSchemas, notification.Error, slog.Uint64(LogKeyTransformID, uint64(transformID)))
+
+	// the state root hash of the new schema is unknown at this point
+	if notification.Error == nil && p.schemaStore != nil {
+		notification.Error = p.schemaStore.Put(context.Background(), "", contractMetadata)
+	}
+
+	p.notifySchemaChanged(notification)
+}
+
+// swapContractSchemas decode the new schemas and replace the observed contract metadata under the write lock,
+// ok is false if the transform URef is not the __events_schema of any observed contract. Other URef writes are
+// checked under the read lock only.
+func (p *EventParser) swapContractSchemas(transform casper.Transform, transformID uint) (SchemaChangedNotification, ContractMetadata, bool) {
+	schemaURef := *transform.Key.URef
+
+	p.mu.RLock()
+	_, ok := p.schemaURefs[schemaURef]
+	p.mu.RUnlock()
+	if !ok {
+		return SchemaChangedNotification{}, ContractMetadata{}, false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	eventsURef := p.schemaURefs[schemaURef]
+	contractMetadata := p.contractsMetadata[eventsURef]
+
+	notification := SchemaChangedNotification{
+		ContractHash: contractMetadata.ContractHash,
+		TransformID:  transformID,
+		OldSchemas:   contractMetadata.Schemas,
+	}
+
+	schemas, err := newSchemasFromTransform(transform, p.limits())
+	if err != nil {
+		notification.Error = err
+		return notification, contractMetadata, true
+	}
+
+	contractMetadata.SetSchemas(schemas)
+	if err = p.validateContractSchemas(&contractMetadata); err != nil {
+		notification.Error = err
+		return notification, contractMetadata, true
+	}
+
+	p.contractsMetadata[eventsURef] = contractMetadata
+
+	notification.NewSchemas = schemas
+	notification.Diff = DiffSchemas(notification.OldSchemas, schemas)
+	return notification, contractMetadata, true
+}
+
+func (p *EventParser) notifySchemaChanged(notification SchemaChangedNotification) {
+	if p.schemaChangedHandler != nil {
+		p.schemaChangedHandler(notification)
+	}
+}
+
+func newSchemasFromTransform(transform casper.Transform, limits DecodeLimits) (Schemas, error) {
+	writeCLValue, err := transform.Kind.ParseAsWriteCLValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// the same as in LoadContractEventSchemas raw bytes are parsed instead of the CLValue which may contain Any type
+	rawBytes, err := writeCLValue.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return newSchemasFromBytes(rawBytes, limits)
+}
@@ -0,0 +1,69 @@
+package ces
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRefresh(t *testing.T) {
+	metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+
+	var notifications []SchemaChangedNotification
+	eventParser := EventParser{
+		schemaChangedHandler: func(notification SchemaChangedNotification) {
+			notifications = append(notifications, notification)
+		},
+	}
+	eventParser.setContractsMetadata(map[string]ContractMetadata{metadata.EventsURef.String(): metadata})
+
+	// new schema contains the only event Mint(amount: U256)
+	const newSchemaHex = "01000000040000004d696e740100000006000000616d6f756e7407"
+
+	var transforms []casper.Transform
+	err := json.Unmarshal([]byte(fmt.Sprintf(`[
+		{"key": "dictionary-e59e8f7779e3a021d70037fb7f63a8422e0f6c3b383b340761a3cfbd0757625c", "kind": {"WriteCLValue": {"cl_type": "Any", "bytes": "%[1]s"}}},
+		{"key": "%[2]s", "kind": {"WriteCLValue": {"cl_type": "Any", "bytes": "%[3]s"}}},
+		{"key": "dictionary-e59e8f7779e3a021d70037fb7f63a8422e0f6c3b383b340761a3cfbd0757625c", "kind": {"WriteCLValue": {"cl_type": "Any", "bytes": "%[1]s"}}}
+	]`, ballotCastEventHex, metadata.EventsSchemaURef.String(), newSchemaHex)), &transforms)
+	require.NoError(t, err)
+
+	results, err := eventParser.ParseExecutionResults(casper.ExecutionResult{Effects: transforms})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// the event before the schema rewrite is parsed with the old schema, the later one with the new schema
+	assert.NoError(t, results[0].Error)
+	assert.ErrorIs(t, results[1].Error, ErrEventNameNotInSchema)
+
+	require.Len(t, notifications, 1)
+	assert.NoError(t, notifications[0].Error)
+	assert.Equal(t, metadata.ContractHash, notifications[0].ContractHash)
+	assert.Equal(t, uint(1), notifications[0].TransformID)
+	assert.Contains(t, notifications[0].NewSchemas, "Mint")
+	assert.True(t, notifications[0].Diff.IsBreaking())
+
+	stored, err := eventParser.contractMetadataByHash(metadata.ContractHash)
+	require.NoError(t, err)
+	assert.Len(t, stored.Schemas, 1)
+
+	t.Run("Refresh turned off", func(t *testing.T) {
+		var eventParser EventParser
+		WithoutSchemaRefresh()(&eventParser)
+		eventParser.setContractsMetadata(map[string]ContractMetadata{metadata.EventsURef.String(): metadata})
+
+		results, err := eventParser.ParseExecutionResults(casper.ExecutionResult{Effects: transforms})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.NoError(t, results[0].Error)
+		assert.NoError(t, results[1].Error)
+
+		stored, err := eventParser.contractMetadataByHash(metadata.ContractHash)
+		require.NoError(t, err)
+		assert.Equal(t, metadata.Schemas.Fingerprint(), stored.Schemas.Fingerprint())
+	})
+}
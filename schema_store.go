@@ -0,0 +1,141 @@
+package ces
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+)
+
+var ErrSchemaNotFound = errors.New("error: schema not found in store")
+
+const defaultMemorySchemaStoreCapacity = 1024
+
+// SchemaStore keeps contract metadata and event schemas loaded from the global state.
+// Entries are keyed by the contract hash and keep the events schema URef and the state root hash they were loaded at.
+// NewParser reads the contract named keys and the event schemas at the latest state root hash and replaces the entry
+// if the contract refers to other URefs or the schemas fingerprint differs from the stored one, the contract upgrade
+// may rewrite __events_schema in place under the same URef.
+type SchemaStore interface {
+	// Get return contract metadata stored for the contract hash or ErrSchemaNotFound
+	Get(ctx context.Context, contractHash casper.Hash) (ContractMetadata, error)
+	// Put store contract metadata loaded at the provided state root hash
+	Put(ctx context.Context, stateRootHash string, metadata ContractMetadata) error
+}
+
+// storedContractMetadata is the serializable representation of ContractMetadata used by persistent stores
+type storedContractMetadata struct {
+	ContractHash        string  `json:"contract_hash"`
+	ContractPackageHash string  `json:"contract_package_hash"`
+	EventsSchemaURef    string  `json:"events_schema_uref"`
+	EventsURef          string  `json:"events_uref"`
+	EventsLengthURef    string  `json:"events_length_uref,omitempty"`
+	StateRootHash       string  `json:"state_root_hash"`
+	Schemas             Schemas `json:"schemas"`
+}
+
+func newStoredContractMetadata(stateRootHash string, metadata ContractMetadata) storedContractMetadata {
+	stored := storedContractMetadata{
+		ContractHash:        metadata.ContractHash.ToHex(),
+		ContractPackageHash: metadata.ContractPackageHash.ToHex(),
+		EventsSchemaURef:    metadata.EventsSchemaURef.String(),
+		EventsURef:          metadata.EventsURef.String(),
+		StateRootHash:       stateRootHash,
+		Schemas:             metadata.Schemas,
+	}
+
+	if metadata.EventsLengthURef != (casper.Uref{}) {
+		stored.EventsLengthURef = metadata.EventsLengthURef.String()
+	}
+
+	return stored
+}
+
+func (s storedContractMetadata) ContractMetadata() (ContractMetadata, error) {
+	var (
+		metadata ContractMetadata
+		err      error
+	)
+
+	metadata.SetSchemas(s.Schemas)
+
+	if metadata.ContractHash, err = casper.NewHash(s.ContractHash); err != nil {
+		return ContractMetadata{}, err
+	}
+
+	if metadata.ContractPackageHash, err = casper.NewHash(s.ContractPackageHash); err != nil {
+		return ContractMetadata{}, err
+	}
+
+	if metadata.EventsSchemaURef, err = casper.NewUref(s.EventsSchemaURef); err != nil {
+		return ContractMetadata{}, err
+	}
+
+	if metadata.EventsURef, err = casper.NewUref(s.EventsURef); err != nil {
+		return ContractMetadata{}, err
+	}
+
+	if s.EventsLengthURef != "" {
+		if metadata.EventsLengthURef, err = casper.NewUref(s.EventsLengthURef); err != nil {
+			return ContractMetadata{}, err
+		}
+	}
+
+	return metadata, nil
+}
+
+// MemorySchemaStore is the in-memory SchemaStore evicting the least recently used entries over the capacity
+type MemorySchemaStore struct {
+	mu       sync.Mutex
+	capacity int
+	// order keeps ContractMetadata values from the most to the least recently used
+	order   *list.List
+	entries map[casper.Hash]*list.Element
+}
+
+func NewMemorySchemaStore(capacity int) *MemorySchemaStore {
+	if capacity <= 0 {
+		capacity = defaultMemorySchemaStoreCapacity
+	}
+
+	return &MemorySchemaStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[casper.Hash]*list.Element, capacity),
+	}
+}
+
+func (s *MemorySchemaStore) Get(_ context.Context, contractHash casper.Hash) (ContractMetadata, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[contractHash]
+	if !ok {
+		return ContractMetadata{}, ErrSchemaNotFound
+	}
+
+	s.order.MoveToFront(element)
+	return element.Value.(ContractMetadata), nil
+}
+
+func (s *MemorySchemaStore) Put(_ context.Context, _ string, metadata ContractMetadata) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[metadata.ContractHash]; ok {
+		element.Value = metadata
+		s.order.MoveToFront(element)
+		return nil
+	}
+
+	s.entries[metadata.ContractHash] = s.order.PushFront(metadata)
+	if s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(ContractMetadata).ContractHash)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package ces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+)
+
+// FileSchemaStore is the on-disk SchemaStore keeping every contract in a separate JSON file named by the contract hash
+type FileSchemaStore struct {
+	dir string
+}
+
+// NewFileSchemaStore create FileSchemaStore in the provided directory, the directory is created if not exists
+func NewFileSchemaStore(dir string) (*FileSchemaStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileSchemaStore{dir: dir}, nil
+}
+
+func (s *FileSchemaStore) Get(_ context.Context, contractHash casper.Hash) (ContractMetadata, error) {
+	data, err := os.ReadFile(s.path(contractHash))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ContractMetadata{}, ErrSchemaNotFound
+		}
+		return ContractMetadata{}, err
+	}
+
+	var stored storedContractMetadata
+	if err = json.Unmarshal(data, &stored); err != nil {
+		return ContractMetadata{}, err
+	}
+
+	return stored.ContractMetadata()
+}
+
+func (s *FileSchemaStore) Put(_ context.Context, stateRootHash string, metadata ContractMetadata) error {
+	data, err := json.Marshal(newStoredContractMetadata(stateRootHash, metadata))
+	if err != nil {
+		return err
+	}
+
+	// write to the temporary file first to never leave partially written entries
+	tmp, err := os.CreateTemp(s.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(metadata.ContractHash))
+}
+
+func (s *FileSchemaStore) path(contractHash casper.Hash) string {
+	return filepath.Join(s.dir, contractHash.ToHex()+".json")
+}
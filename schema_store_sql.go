@@ -0,0 +1,123 @@
+package ces
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+)
+
+const defaultSQLSchemaStoreTable = "ces_contract_schemas"
+
+type (
+	// SQLSchemaStore is the SchemaStore backed by database/sql, schemas are stored with Schemas.Value and read with Schemas.Scan.
+	// The table is expected to be created by the caller, e.g.:
+	//
+	//	CREATE TABLE ces_contract_schemas (
+	//		contract_hash         VARCHAR(64) PRIMARY KEY,
+	//		contract_package_hash VARCHAR(64) NOT NULL,
+	//		events_schema_uref    VARCHAR(80) NOT NULL,
+	//		events_uref           VARCHAR(80) NOT NULL,
+	//		events_length_uref    VARCHAR(80) NOT NULL,
+	//		state_root_hash       VARCHAR(64) NOT NULL,
+	//		schemas               BLOB        NOT NULL
+	//	);
+	SQLSchemaStore struct {
+		db          *sql.DB
+		table       string
+		placeholder func(n int) string
+	}
+
+	SQLSchemaStoreOption func(*SQLSchemaStore)
+)
+
+// WithSQLTable set the table name, ces_contract_schemas is used by default
+func WithSQLTable(table string) SQLSchemaStoreOption {
+	return func(s *SQLSchemaStore) {
+		s.table = table
+	}
+}
+
+// WithSQLDollarPlaceholders make SQLSchemaStore use $1, $2... query placeholders (e.g. for PostgreSQL) instead of ?
+func WithSQLDollarPlaceholders() SQLSchemaStoreOption {
+	return func(s *SQLSchemaStore) {
+		s.placeholder = func(n int) string {
+			return fmt.Sprintf("$%d", n)
+		}
+	}
+}
+
+func NewSQLSchemaStore(db *sql.DB, opts ...SQLSchemaStoreOption) *SQLSchemaStore {
+	store := &SQLSchemaStore{
+		db:    db,
+		table: defaultSQLSchemaStoreTable,
+		placeholder: func(int) string {
+			return "?"
+		},
+	}
+
+	for _, opt := range opts {
+		opt(store)
+	}
+
+	return store
+}
+
+func (s *SQLSchemaStore) Get(ctx context.Context, contractHash casper.Hash) (ContractMetadata, error) {
+	query := fmt.Sprintf(`SELECT contract_hash, contract_package_hash, events_schema_uref, events_uref, events_length_uref, state_root_hash, schemas
+		FROM %s WHERE contract_hash = %s`, s.table, s.placeholder(1))
+
+	var stored storedContractMetadata
+	err := s.db.QueryRowContext(ctx, query, contractHash.ToHex()).Scan(
+		&stored.ContractHash,
+		&stored.ContractPackageHash,
+		&stored.EventsSchemaURef,
+		&stored.EventsURef,
+		&stored.EventsLengthURef,
+		&stored.StateRootHash,
+		&stored.Schemas,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ContractMetadata{}, ErrSchemaNotFound
+		}
+		return ContractMetadata{}, err
+	}
+
+	return stored.ContractMetadata()
+}
+
+func (s *SQLSchemaStore) Put(ctx context.Context, stateRootHash string, metadata ContractMetadata) error {
+	stored := newStoredContractMetadata(stateRootHash, metadata)
+
+	// delete and insert in the transaction instead of the dialect specific upsert
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	deleteQuery := fmt.Sprintf(`DELETE FROM %s WHERE contract_hash = %s`, s.table, s.placeholder(1))
+	if _, err = tx.ExecContext(ctx, deleteQuery, stored.ContractHash); err != nil {
+		return err
+	}
+
+	insertQuery := fmt.Sprintf(`INSERT INTO %s (contract_hash, contract_package_hash, events_schema_uref, events_uref, events_length_uref, state_root_hash, schemas)
+		VALUES (%s, %s, %s, %s, %s, %s, %s)`, s.table,
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4), s.placeholder(5), s.placeholder(6), s.placeholder(7))
+	if _, err = tx.ExecContext(ctx, insertQuery,
+		stored.ContractHash,
+		stored.ContractPackageHash,
+		stored.EventsSchemaURef,
+		stored.EventsURef,
+		stored.EventsLengthURef,
+		stored.StateRootHash,
+		stored.Schemas,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,101 @@
+package ces
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLSchemaStore(t *testing.T) {
+	ctx := context.Background()
+	stateRootHash := "002596e815c7235dccf76358695de0088b4636ecb2473c12bb5ff0fbbb7ae94a"
+	metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+
+	schemasValue, err := metadata.Schemas.Value()
+	require.NoError(t, err)
+
+	columns := []string{"contract_hash", "contract_package_hash", "events_schema_uref", "events_uref", "events_length_uref", "state_root_hash", "schemas"}
+
+	t.Run("Put and Get", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		store := NewSQLSchemaStore(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM ces_contract_schemas WHERE contract_hash = ?`)).
+			WithArgs(metadata.ContractHash.ToHex()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO ces_contract_schemas`)).
+			WithArgs(
+				metadata.ContractHash.ToHex(),
+				metadata.ContractPackageHash.ToHex(),
+				metadata.EventsSchemaURef.String(),
+				metadata.EventsURef.String(),
+				"",
+				stateRootHash,
+				schemasValue,
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, store.Put(ctx, stateRootHash, metadata))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM ces_contract_schemas WHERE contract_hash = ?`)).
+			WithArgs(metadata.ContractHash.ToHex()).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(
+				metadata.ContractHash.ToHex(),
+				metadata.ContractPackageHash.ToHex(),
+				metadata.EventsSchemaURef.String(),
+				metadata.EventsURef.String(),
+				"",
+				stateRootHash,
+				schemasValue,
+			))
+
+		stored, err := store.Get(ctx, metadata.ContractHash)
+		require.NoError(t, err)
+		assert.Equal(t, metadata.ContractHash, stored.ContractHash)
+		assert.Equal(t, metadata.ContractPackageHash, stored.ContractPackageHash)
+		assert.Equal(t, metadata.EventsSchemaURef, stored.EventsSchemaURef)
+		assert.Equal(t, metadata.EventsURef, stored.EventsURef)
+		assert.Equal(t, metadata.Schemas.Fingerprint(), stored.SchemasFingerprint)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM contract_schemas WHERE contract_hash = $1`)).
+			WithArgs(metadata.ContractHash.ToHex()).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err = NewSQLSchemaStore(db, WithSQLTable("contract_schemas"), WithSQLDollarPlaceholders()).Get(ctx, metadata.ContractHash)
+		assert.ErrorIs(t, err, ErrSchemaNotFound)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Failed insert is rolled back", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM ces_contract_schemas`)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO ces_contract_schemas`)).WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, NewSQLSchemaStore(db).Put(ctx, stateRootHash, metadata), assert.AnError)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,253 @@
+package ces
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/rpc"
+	"github.com/make-software/casper-go-sdk/v2/types/key"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2/utils/mocks"
+)
+
+func newTestContractMetadata(t testing.TB, contractHashHex string) ContractMetadata {
+	contractHash, err := casper.NewHash(contractHashHex)
+	require.NoError(t, err)
+
+	contractPackageHash, err := casper.NewHash("7a5fce1d9ad45c9d71a5e59638602213295a51a6cf92518f8b262cd3e23d6d7e")
+	require.NoError(t, err)
+
+	eventsURef, err := casper.NewUref("uref-d2263e86f497f42e405d5d1390aa3c1a8bfc35f3699fdc3be806a5cfe139dac9-007")
+	require.NoError(t, err)
+
+	eventsSchemaURef, err := casper.NewUref("uref-12263e86f497f42e405d5d1390aa3c1a8bfc35f3699fdc3be806a5cfe139dac9-007")
+	require.NoError(t, err)
+
+	schemaBytes, err := hex.DecodeString(votingSchemaHex)
+	require.NoError(t, err)
+
+	schemas, err := NewSchemasFromBytes(schemaBytes)
+	require.NoError(t, err)
+
+	return ContractMetadata{
+		Schemas:             schemas,
+		ContractHash:        contractHash,
+		ContractPackageHash: contractPackageHash,
+		EventsSchemaURef:    eventsSchemaURef,
+		EventsURef:          eventsURef,
+	}
+}
+
+// expectTestContract make the node return the contract of metadata referring to the provided __events_schema URef
+func expectTestContract(t testing.TB, mockedClient *mocks.MockStateReader, metadata ContractMetadata, eventsSchemaURef string) {
+	stateRootHash, err := casper.NewHash("002596e815c7235dccf76358695de0088b4636ecb2473c12bb5ff0fbbb7ae94a")
+	require.NoError(t, err)
+
+	eventsURefKey, err := key.NewKey(metadata.EventsURef.String())
+	require.NoError(t, err)
+	eventsSchemaURefKey, err := key.NewKey(eventsSchemaURef)
+	require.NoError(t, err)
+
+	mockedClient.EXPECT().GetStateRootHashLatest(gomock.Any()).Return(casper.ChainGetStateRootHashResult{StateRootHash: stateRootHash}, nil)
+	mockedClient.EXPECT().QueryGlobalStateByStateHash(gomock.Any(), gomock.Any(), fmt.Sprintf("hash-%s", metadata.ContractHash), nil).Return(rpc.QueryGlobalStateResult{
+		StoredValue: casper.StoredValue{Contract: &casper.Contract{
+			ContractPackageHash: casper.ContractPackageHash{Hash: metadata.ContractPackageHash},
+			NamedKeys: casper.NamedKeys{
+				{Name: eventNamedKey, Key: eventsURefKey},
+				{Name: eventSchemaNamedKey, Key: eventsSchemaURefKey},
+			},
+		}},
+	}, nil)
+}
+
+// expectTestSchema make the node return the hex encoded schemas under the __events_schema URef
+func expectTestSchema(t testing.TB, mockedClient *mocks.MockStateReader, eventsSchemaURef string, schemasHex string) {
+	var schemaArg casper.Argument
+	err := json.Unmarshal([]byte(fmt.Sprintf(`{"cl_type": "Any", "bytes": "%s"}`, schemasHex)), &schemaArg)
+	require.NoError(t, err)
+
+	mockedClient.EXPECT().QueryGlobalStateByStateHash(gomock.Any(), gomock.Any(), eventsSchemaURef, nil).Return(rpc.QueryGlobalStateResult{
+		StoredValue: casper.StoredValue{CLValue: &schemaArg},
+	}, nil)
+}
+
+// encodeSchemasHex encode schemas in the __events_schema value format ordered by the event name
+func encodeSchemasHex(schemas Schemas) string {
+	data := lengthPrefix(uint32(len(schemas)))
+	for _, name := range sortedEventNames(schemas) {
+		data = append(append(data, lengthPrefix(uint32(len(name)))...), name...)
+		data = append(data, lengthPrefix(uint32(len(schemas[name])))...)
+		for _, field := range schemas[name] {
+			data = append(append(data, lengthPrefix(uint32(len(field.ParamName)))...), field.ParamName...)
+			data = append(data, field.ParamType.Bytes()...)
+		}
+	}
+	return hex.EncodeToString(data)
+}
+
+func TestMemorySchemaStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySchemaStore(2)
+
+	first := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	second := newTestContractMetadata(t, "0640eb43bd95d5c88b799862bc9fb42d7a241f1a8aae5deaa03170a27ee8eeaa")
+	third := newTestContractMetadata(t, "e7062b42c9a22002fa3cd216debd605b7056ad180efb3c99555676f1a1e801e5")
+
+	require.NoError(t, store.Put(ctx, "", first))
+	require.NoError(t, store.Put(ctx, "", second))
+
+	// touch the first entry to make the second one the least recently used
+	_, err := store.Get(ctx, first.ContractHash)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "", third))
+
+	_, err = store.Get(ctx, second.ContractHash)
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+
+	stored, err := store.Get(ctx, first.ContractHash)
+	require.NoError(t, err)
+	assert.Equal(t, first.ContractHash, stored.ContractHash)
+
+	_, err = store.Get(ctx, third.ContractHash)
+	assert.NoError(t, err)
+}
+
+func TestFileSchemaStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileSchemaStore(t.TempDir())
+	require.NoError(t, err)
+
+	metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+
+	_, err = store.Get(ctx, metadata.ContractHash)
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+
+	require.NoError(t, store.Put(ctx, "002596e815c7235dccf76358695de0088b4636ecb2473c12bb5ff0fbbb7ae94a", metadata))
+
+	stored, err := store.Get(ctx, metadata.ContractHash)
+	require.NoError(t, err)
+	assert.Equal(t, metadata.ContractHash, stored.ContractHash)
+	assert.Equal(t, metadata.ContractPackageHash, stored.ContractPackageHash)
+	assert.Equal(t, metadata.EventsURef.String(), stored.EventsURef.String())
+	assert.Equal(t, metadata.EventsSchemaURef.String(), stored.EventsSchemaURef.String())
+	require.Len(t, stored.Schemas, len(metadata.Schemas))
+	for name, schema := range metadata.Schemas {
+		require.Len(t, stored.Schemas[name], len(schema))
+		for i, field := range schema {
+			assert.Equal(t, field.ParamName, stored.Schemas[name][i].ParamName)
+			assert.Equal(t, field.ParamType.Bytes(), stored.Schemas[name][i].ParamType.Bytes())
+		}
+	}
+}
+
+// countingSchemaStore count Put calls of the wrapped store
+type countingSchemaStore struct {
+	SchemaStore
+	puts int
+}
+
+func (s *countingSchemaStore) Put(ctx context.Context, stateRootHash string, metadata ContractMetadata) error {
+	s.puts++
+	return s.SchemaStore.Put(ctx, stateRootHash, metadata)
+}
+
+func TestNewParserWithSchemaStore(t *testing.T) {
+	metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+
+	stateRootHash, err := casper.NewHash("002596e815c7235dccf76358695de0088b4636ecb2473c12bb5ff0fbbb7ae94a")
+	require.NoError(t, err)
+	stateRoot := stateRootHash.ToHex()
+
+	var schemaArg casper.Argument
+	err = json.Unmarshal([]byte(fmt.Sprintf(`{"cl_type": "Any", "bytes": "%s"}`, votingSchemaHex)), &schemaArg)
+	require.NoError(t, err)
+
+	t.Run("Unchanged schemas are kept", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockedClient := mocks.NewMockStateReader(mockCtrl)
+		expectTestContract(t, mockedClient, metadata, metadata.EventsSchemaURef.String())
+		expectTestSchema(t, mockedClient, metadata.EventsSchemaURef.String(), votingSchemaHex)
+
+		stored := metadata
+		stored.SetSchemas(metadata.Schemas)
+		store := &countingSchemaStore{SchemaStore: NewMemorySchemaStore(0)}
+		require.NoError(t, store.SchemaStore.Put(context.Background(), "", stored))
+
+		parser, err := NewParser(mockedClient, []casper.Hash{metadata.ContractHash}, WithSchemaStore(store))
+		require.NoError(t, err)
+
+		loaded, err := parser.contractMetadataByHash(metadata.ContractHash)
+		require.NoError(t, err)
+		assert.Equal(t, metadata.EventsURef, loaded.EventsURef)
+		assert.Equal(t, metadata.Schemas.Fingerprint(), loaded.SchemasFingerprint)
+		assert.Zero(t, store.puts)
+	})
+
+	t.Run("Schemas rewritten under the same URef are replaced", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		// the contract upgrade rewrote __events_schema in place, the node returns the new content of the same URef
+		mockedClient := mocks.NewMockStateReader(mockCtrl)
+		expectTestContract(t, mockedClient, metadata, metadata.EventsSchemaURef.String())
+		expectTestSchema(t, mockedClient, metadata.EventsSchemaURef.String(), votingSchemaHex)
+
+		stale := metadata
+		stale.SetSchemas(Schemas{"SimpleVotingCreated": metadata.Schemas["SimpleVotingCreated"]})
+		store := &countingSchemaStore{SchemaStore: NewMemorySchemaStore(0)}
+		require.NoError(t, store.SchemaStore.Put(context.Background(), "", stale))
+
+		parser, err := NewParser(mockedClient, []casper.Hash{metadata.ContractHash}, WithSchemaStore(store))
+		require.NoError(t, err)
+
+		loaded, err := parser.contractMetadataByHash(metadata.ContractHash)
+		require.NoError(t, err)
+		assert.Equal(t, metadata.EventsSchemaURef, loaded.EventsSchemaURef)
+		assert.Len(t, loaded.Schemas, len(metadata.Schemas))
+
+		stored, err := store.Get(context.Background(), metadata.ContractHash)
+		require.NoError(t, err)
+		assert.Equal(t, metadata.Schemas.Fingerprint(), stored.SchemasFingerprint)
+		assert.Equal(t, 1, store.puts)
+	})
+
+	t.Run("Stale schemas are reloaded", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		upgradedSchemaURef := "uref-42263e86f497f42e405d5d1390aa3c1a8bfc35f3699fdc3be806a5cfe139dac9-007"
+		mockedClient := mocks.NewMockStateReader(mockCtrl)
+		expectTestContract(t, mockedClient, metadata, upgradedSchemaURef)
+		mockedClient.EXPECT().QueryGlobalStateByStateHash(gomock.Any(), &stateRoot, upgradedSchemaURef, nil).Return(rpc.QueryGlobalStateResult{
+			StoredValue: casper.StoredValue{CLValue: &schemaArg},
+		}, nil)
+
+		stale := metadata
+		stale.SetSchemas(Schemas{"SimpleVotingCreated": metadata.Schemas["SimpleVotingCreated"]})
+		store := NewMemorySchemaStore(0)
+		require.NoError(t, store.Put(context.Background(), "", stale))
+
+		parser, err := NewParser(mockedClient, []casper.Hash{metadata.ContractHash}, WithSchemaStore(store))
+		require.NoError(t, err)
+
+		loaded, err := parser.contractMetadataByHash(metadata.ContractHash)
+		require.NoError(t, err)
+		assert.Equal(t, upgradedSchemaURef, loaded.EventsSchemaURef.String())
+		assert.Len(t, loaded.Schemas, len(metadata.Schemas))
+
+		stored, err := store.Get(context.Background(), metadata.ContractHash)
+		require.NoError(t, err)
+		assert.Equal(t, upgradedSchemaURef, stored.EventsSchemaURef.String())
+		assert.Equal(t, metadata.Schemas.Fingerprint(), stored.SchemasFingerprint)
+	})
+}
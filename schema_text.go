@@ -0,0 +1,381 @@
+package ces
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+)
+
+var ErrInvalidSchemaText = errors.New("error: invalid schema text")
+
+var simpleTypesByName = map[string]cltype.CLType{
+	"Bool":      cltype.Bool,
+	"I32":       cltype.Int32,
+	"I64":       cltype.Int64,
+	"U8":        cltype.UInt8,
+	"U32":       cltype.UInt32,
+	"U64":       cltype.UInt64,
+	"U128":      cltype.UInt128,
+	"U256":      cltype.UInt256,
+	"U512":      cltype.UInt512,
+	"Unit":      cltype.Unit,
+	"String":    cltype.String,
+	"Key":       cltype.Key,
+	"URef":      cltype.Uref,
+	"PublicKey": cltype.PublicKey,
+	"Any":       cltype.Any,
+}
+
+var simpleTypeNames = func() map[cltype.TypeID]string {
+	names := make(map[cltype.TypeID]string, len(simpleTypesByName))
+	for name, clType := range simpleTypesByName {
+		names[clType.GetTypeID()] = name
+	}
+	return names
+}()
+
+// NewSchemasFromText parse schemas written in the text format, e.g.
+//
+//	// comments run to the end of line
+//	event Transfer { from: Key, to: Key, amount: U256, memo: Option<String> }
+//	event Snapshot { holders: Map<Key, U512>, checksum: ByteArray(32), range: Tuple<U64, U64> }
+//
+// Composite types are Option<T>, List<T>, Map<K, V>, Result<Ok, Err>, Tuple<T1[, T2[, T3]]> and ByteArray(N).
+// Event and field names which are not identifiers are written as Go quoted strings, e.g. event "Transfer-v2" { "to address": Key }
+func NewSchemasFromText(text string) (Schemas, error) {
+	parser := schemaTextParser{text: text, line: 1, column: 1}
+
+	schemas := make(Schemas)
+	for {
+		parser.skipSpace()
+		if parser.eof() {
+			return schemas, nil
+		}
+
+		if err := parser.expectWord("event"); err != nil {
+			return nil, err
+		}
+
+		name, err := parser.name()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := schemas[name]; ok {
+			return nil, parser.errorf("duplicate event %s", name)
+		}
+
+		fields, err := parser.fields()
+		if err != nil {
+			return nil, err
+		}
+		schemas[name] = fields
+	}
+}
+
+// FormatSchemas print schemas in the text format accepted by NewSchemasFromText, one event per line ordered by the name
+func FormatSchemas(schemas Schemas) string {
+	var builder strings.Builder
+	for _, name := range sortedEventNames(schemas) {
+		builder.WriteString("event ")
+		builder.WriteString(formatSchemaName(name))
+		builder.WriteString(" {")
+		for i, field := range schemas[name] {
+			if i > 0 {
+				builder.WriteString(",")
+			}
+			builder.WriteString(" ")
+			builder.WriteString(formatSchemaName(field.ParamName))
+			builder.WriteString(": ")
+			builder.WriteString(FormatCLType(field.ParamType))
+		}
+		if len(schemas[name]) > 0 {
+			builder.WriteString(" ")
+		}
+		builder.WriteString("}\n")
+	}
+	return builder.String()
+}
+
+// formatSchemaName print event or field name as is if it is an identifier, otherwise as a quoted string
+func formatSchemaName(name string) string {
+	if isSchemaIdentifier(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// isSchemaIdentifier report whether the name is a letter or underscore followed by letters, digits and underscores
+func isSchemaIdentifier(name string) bool {
+	for i, r := range name {
+		if !isIdentifierRune(r, i == 0) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+func isIdentifierRune(r rune, first bool) bool {
+	return r == '_' || unicode.IsLetter(r) || (!first && unicode.IsDigit(r))
+}
+
+// FormatCLType print CLType in the schema text format
+func FormatCLType(clType cltype.CLType) string {
+	switch one := clType.(type) {
+	case nil:
+		return "<nil>"
+	case *cltype.Option:
+		return "Option<" + FormatCLType(one.Inner) + ">"
+	case *cltype.List:
+		return "List<" + FormatCLType(one.ElementsType) + ">"
+	case *cltype.ByteArray:
+		return fmt.Sprintf("ByteArray(%d)", one.Size)
+	case *cltype.Result:
+		return "Result<" + FormatCLType(one.InnerOk) + ", " + FormatCLType(one.InnerErr) + ">"
+	case *cltype.Map:
+		return "Map<" + FormatCLType(one.Key) + ", " + FormatCLType(one.Val) + ">"
+	case *cltype.Tuple1:
+		return "Tuple<" + FormatCLType(one.Inner1) + ">"
+	case *cltype.Tuple2:
+		return "Tuple<" + FormatCLType(one.Inner1) + ", " + FormatCLType(one.Inner2) + ">"
+	case *cltype.Tuple3:
+		return "Tuple<" + FormatCLType(one.Inner1) + ", " + FormatCLType(one.Inner2) + ", " + FormatCLType(one.Inner3) + ">"
+	}
+
+	if name, ok := simpleTypeNames[clType.GetTypeID()]; ok {
+		return name
+	}
+	return clType.Name()
+}
+
+type schemaTextParser struct {
+	text         string
+	pos          int
+	line, column int
+}
+
+func (p *schemaTextParser) fields() ([]SchemaData, error) {
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+
+	fields := make([]SchemaData, 0)
+	for {
+		if p.consume('}') {
+			return fields, nil
+		}
+
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(':'); err != nil {
+			return nil, err
+		}
+		clType, err := p.clType()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, SchemaData{ParamName: name, ParamType: clType})
+
+		if !p.consume(',') {
+			if err = p.expect('}'); err != nil {
+				return nil, err
+			}
+			return fields, nil
+		}
+	}
+}
+
+func (p *schemaTextParser) clType() (cltype.CLType, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+
+	if simple, ok := simpleTypesByName[name]; ok {
+		return simple, nil
+	}
+
+	switch name {
+	case "ByteArray":
+		if err = p.expect('('); err != nil {
+			return nil, err
+		}
+		size, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(')'); err != nil {
+			return nil, err
+		}
+		return &cltype.ByteArray{Size: size}, nil
+	case "Option", "List", "Map", "Result", "Tuple":
+		inner, err := p.typeArguments()
+		if err != nil {
+			return nil, err
+		}
+		return newCompositeType(name, inner, p)
+	default:
+		return nil, p.errorf("unknown type %s", name)
+	}
+}
+
+func newCompositeType(name string, inner []cltype.CLType, p *schemaTextParser) (cltype.CLType, error) {
+	switch {
+	case name == "Option" && len(inner) == 1:
+		return &cltype.Option{Inner: inner[0]}, nil
+	case name == "List" && len(inner) == 1:
+		return &cltype.List{ElementsType: inner[0]}, nil
+	case name == "Map" && len(inner) == 2:
+		return &cltype.Map{Key: inner[0], Val: inner[1]}, nil
+	case name == "Result" && len(inner) == 2:
+		return &cltype.Result{InnerOk: inner[0], InnerErr: inner[1]}, nil
+	case name == "Tuple" && len(inner) == 1:
+		return &cltype.Tuple1{Inner1: inner[0]}, nil
+	case name == "Tuple" && len(inner) == 2:
+		return &cltype.Tuple2{Inner1: inner[0], Inner2: inner[1]}, nil
+	case name == "Tuple" && len(inner) == 3:
+		return &cltype.Tuple3{Inner1: inner[0], Inner2: inner[1], Inner3: inner[2]}, nil
+	default:
+		return nil, p.errorf("unexpected number of %s type arguments %d", name, len(inner))
+	}
+}
+
+func (p *schemaTextParser) typeArguments() ([]cltype.CLType, error) {
+	if err := p.expect('<'); err != nil {
+		return nil, err
+	}
+
+	var inner []cltype.CLType
+	for {
+		clType, err := p.clType()
+		if err != nil {
+			return nil, err
+		}
+		inner = append(inner, clType)
+
+		if !p.consume(',') {
+			return inner, p.expect('>')
+		}
+	}
+}
+
+// name read event or field name written either as identifier or as quoted string
+func (p *schemaTextParser) name() (string, error) {
+	p.skipSpace()
+	if p.eof() || p.text[p.pos] != '"' {
+		return p.identifier()
+	}
+
+	quoted, err := strconv.QuotedPrefix(p.text[p.pos:])
+	if err != nil {
+		return "", p.errorf("invalid quoted name")
+	}
+	name, err := strconv.Unquote(quoted)
+	if err != nil || name == "" {
+		return "", p.errorf("invalid quoted name")
+	}
+
+	for end := p.pos + len(quoted); p.pos < end; {
+		p.advance()
+	}
+	return name, nil
+}
+
+func (p *schemaTextParser) identifier() (string, error) {
+	p.skipSpace()
+
+	start := p.pos
+	for !p.eof() {
+		r, _ := utf8.DecodeRuneInString(p.text[p.pos:])
+		if !isIdentifierRune(r, p.pos == start) {
+			break
+		}
+		p.advance()
+	}
+
+	if start == p.pos {
+		return "", p.errorf("expected identifier")
+	}
+	return p.text[start:p.pos], nil
+}
+
+func (p *schemaTextParser) number() (uint32, error) {
+	p.skipSpace()
+
+	start := p.pos
+	for !p.eof() && p.text[p.pos] >= '0' && p.text[p.pos] <= '9' {
+		p.advance()
+	}
+
+	number, err := strconv.ParseUint(p.text[start:p.pos], 10, 32)
+	if err != nil {
+		return 0, p.errorf("expected number")
+	}
+	return uint32(number), nil
+}
+
+func (p *schemaTextParser) expectWord(word string) error {
+	identifier, err := p.identifier()
+	if err != nil || identifier != word {
+		return p.errorf("expected %s", word)
+	}
+	return nil
+}
+
+func (p *schemaTextParser) expect(char byte) error {
+	if !p.consume(char) {
+		return p.errorf("expected %q", char)
+	}
+	return nil
+}
+
+func (p *schemaTextParser) consume(char byte) bool {
+	p.skipSpace()
+	if p.eof() || p.text[p.pos] != char {
+		return false
+	}
+	p.advance()
+	return true
+}
+
+// skipSpace skip whitespaces and line comments
+func (p *schemaTextParser) skipSpace() {
+	for !p.eof() {
+		r, _ := utf8.DecodeRuneInString(p.text[p.pos:])
+		switch {
+		case unicode.IsSpace(r):
+			p.advance()
+		case strings.HasPrefix(p.text[p.pos:], "//"):
+			for !p.eof() && p.text[p.pos] != '\n' {
+				p.advance()
+			}
+		default:
+			return
+		}
+	}
+}
+
+// advance move to the next rune, the column counts runes
+func (p *schemaTextParser) advance() {
+	if p.text[p.pos] == '\n' {
+		p.line++
+		p.column = 0
+	}
+	_, size := utf8.DecodeRuneInString(p.text[p.pos:])
+	p.pos += size
+	p.column++
+}
+
+func (p *schemaTextParser) eof() bool {
+	return p.pos >= len(p.text)
+}
+
+func (p *schemaTextParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: line %d column %d: %s", ErrInvalidSchemaText, p.line, p.column, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,88 @@
+package ces
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSchemasFromText(t *testing.T) {
+	schemas, err := NewSchemasFromText(`
+		// CEP-18 like transfer
+		event Transfer { from: Key, to: Key, amount: U256, memo: Option<String> }
+		event Snapshot {
+			holders: Map<Key, List<U512>>,
+			checksum: ByteArray(32),
+			outcome: Result<Unit, Tuple<U8, String>>,
+		}
+		event Empty {}
+	`)
+	require.NoError(t, err)
+
+	assert.Equal(t, Schemas{
+		"Transfer": {
+			{ParamName: "from", ParamType: cltype.Key},
+			{ParamName: "to", ParamType: cltype.Key},
+			{ParamName: "amount", ParamType: cltype.UInt256},
+			{ParamName: "memo", ParamType: &cltype.Option{Inner: cltype.String}},
+		},
+		"Snapshot": {
+			{ParamName: "holders", ParamType: &cltype.Map{Key: cltype.Key, Val: &cltype.List{ElementsType: cltype.UInt512}}},
+			{ParamName: "checksum", ParamType: &cltype.ByteArray{Size: 32}},
+			{ParamName: "outcome", ParamType: &cltype.Result{InnerOk: cltype.Unit, InnerErr: &cltype.Tuple2{Inner1: cltype.UInt8, Inner2: cltype.String}}},
+		},
+		"Empty": {},
+	}, schemas)
+
+	assert.Equal(t, `event Empty {}
+event Snapshot { holders: Map<Key, List<U512>>, checksum: ByteArray(32), outcome: Result<Unit, Tuple<U8, String>> }
+event Transfer { from: Key, to: Key, amount: U256, memo: Option<String> }
+`, FormatSchemas(schemas))
+
+	t.Run("Round trip", func(t *testing.T) {
+		schemaBytes, err := hex.DecodeString(votingSchemaHex)
+		require.NoError(t, err)
+		schemas, err := NewSchemasFromBytes(schemaBytes)
+		require.NoError(t, err)
+
+		parsed, err := NewSchemasFromText(FormatSchemas(schemas))
+		require.NoError(t, err)
+		assert.Equal(t, schemas.Fingerprint(), parsed.Fingerprint())
+	})
+
+	t.Run("Non-ASCII and quoted names", func(t *testing.T) {
+		schemas := Schemas{
+			"Überweisung": {{ParamName: "empfänger", ParamType: cltype.Key}},
+			"Transfer-v2": {{ParamName: "to address", ParamType: cltype.Key}, {ParamName: "1st", ParamType: cltype.UInt8}},
+		}
+
+		text := FormatSchemas(schemas)
+		assert.Equal(t, `event "Transfer-v2" { "to address": Key, "1st": U8 }
+event Überweisung { empfänger: Key }
+`, text)
+
+		parsed, err := NewSchemasFromText(text)
+		require.NoError(t, err)
+		assert.Equal(t, schemas, parsed)
+	})
+
+	t.Run("Invalid text", func(t *testing.T) {
+		for _, text := range []string{
+			"Transfer { from: Key }",
+			"event Transfer { from: Key",
+			"event Transfer { from: U1024 }",
+			"event Transfer { from: Map<Key> }",
+			"event Transfer { from: ByteArray(x) }",
+			"event Transfer {}\nevent Transfer {}",
+			`event "Transfer { from: Key }`,
+			`event "" { from: Key }`,
+			"event Trans\u00a0fer { from: Key }",
+		} {
+			_, err := NewSchemasFromText(text)
+			assert.ErrorIs(t, err, ErrInvalidSchemaText, text)
+		}
+	})
+}
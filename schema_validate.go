@@ -0,0 +1,176 @@
+package ces
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+)
+
+var ErrInvalidSchema = errors.New("error: invalid schema")
+
+type SchemaIssueKind int
+
+const (
+	// SchemaIssueUnsupportedType reports field CLType the decoder cannot handle, e.g. Any
+	SchemaIssueUnsupportedType SchemaIssueKind = iota + 1
+	// SchemaIssueDuplicateField reports field name used more than once in the event
+	SchemaIssueDuplicateField
+	// SchemaIssueEmptyEvent reports event without fields, e.g. CEP-78 VariablesSet. Fieldless events are valid CES,
+	// the issue is a warning ignored by Schemas.Validate.
+	SchemaIssueEmptyEvent
+)
+
+func (k SchemaIssueKind) String() string {
+	switch k {
+	case SchemaIssueUnsupportedType:
+		return "unsupported_type"
+	case SchemaIssueDuplicateField:
+		return "duplicate_field"
+	case SchemaIssueEmptyEvent:
+		return "empty_event"
+	default:
+		return "unknown"
+	}
+}
+
+// SchemaValidationMode defines how EventParser treats invalid contract schemas
+type SchemaValidationMode int
+
+const (
+	// SchemaValidationOff skips schema validation
+	SchemaValidationOff SchemaValidationMode = iota
+	// SchemaValidationWarn keeps invalid schemas and records found issues in ContractMetadata.SchemaIssues
+	SchemaValidationWarn
+	// SchemaValidationRefuse rejects invalid schemas
+	SchemaValidationRefuse
+)
+
+// IsWarning check the issue kind reports valid but unusual schema, warnings do not fail Schemas.Validate
+func (k SchemaIssueKind) IsWarning() bool {
+	return k == SchemaIssueEmptyEvent
+}
+
+type (
+	SchemaIssue struct {
+		Kind      SchemaIssueKind
+		EventName EventName
+		// FieldName is empty for SchemaIssueEmptyEvent
+		FieldName string
+		// FieldType is set for SchemaIssueUnsupportedType
+		FieldType cltype.CLType
+	}
+
+	// SchemaValidationError lists all issues found in schemas, it matches ErrInvalidSchema with errors.Is
+	SchemaValidationError struct {
+		Issues []SchemaIssue
+	}
+)
+
+func (i SchemaIssue) String() string {
+	switch i.Kind {
+	case SchemaIssueEmptyEvent:
+		return fmt.Sprintf("%s: %s", i.Kind, i.EventName)
+	case SchemaIssueUnsupportedType:
+		return fmt.Sprintf("%s: %s.%s %s", i.Kind, i.EventName, i.FieldName, i.FieldType)
+	default:
+		return fmt.Sprintf("%s: %s.%s", i.Kind, i.EventName, i.FieldName)
+	}
+}
+
+func (e *SchemaValidationError) Error() string {
+	issues := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		issues = append(issues, issue.String())
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidSchema, strings.Join(issues, "; "))
+}
+
+func (e *SchemaValidationError) Unwrap() error {
+	return ErrInvalidSchema
+}
+
+// Validate check that event fields have unique names and CLTypes supported by the decoder,
+// returns *SchemaValidationError listing all found issues except warnings
+func (t Schemas) Validate() error {
+	var issues []SchemaIssue
+	for _, issue := range t.Issues() {
+		if !issue.Kind.IsWarning() {
+			issues = append(issues, issue)
+		}
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+
+	return &SchemaValidationError{Issues: issues}
+}
+
+// Issues return all schema issues including warnings ordered by the event name and field position
+func (t Schemas) Issues() []SchemaIssue {
+	var issues []SchemaIssue
+	for _, name := range sortedEventNames(t) {
+		fields := t[name]
+		if len(fields) == 0 {
+			issues = append(issues, SchemaIssue{Kind: SchemaIssueEmptyEvent, EventName: name})
+			continue
+		}
+
+		seen := make(map[string]struct{}, len(fields))
+		for _, field := range fields {
+			if _, ok := seen[field.ParamName]; ok {
+				issues = append(issues, SchemaIssue{Kind: SchemaIssueDuplicateField, EventName: name, FieldName: field.ParamName})
+			}
+			seen[field.ParamName] = struct{}{}
+
+			if !isDecodableType(field.ParamType) {
+				issues = append(issues, SchemaIssue{
+					Kind:      SchemaIssueUnsupportedType,
+					EventName: name,
+					FieldName: field.ParamName,
+					FieldType: field.ParamType,
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// isDecodableType check the CLType and all its nested types could be decoded from the event payload
+func isDecodableType(clType cltype.CLType) bool {
+	switch one := clType.(type) {
+	case nil:
+		return false
+	case *cltype.Option:
+		return isDecodableType(one.Inner)
+	case *cltype.List:
+		return isDecodableType(one.ElementsType)
+	case *cltype.ByteArray:
+		return true
+	case *cltype.Result:
+		return isDecodableType(one.InnerOk) && isDecodableType(one.InnerErr)
+	case *cltype.Map:
+		return isDecodableType(one.Key) && isDecodableType(one.Val)
+	case *cltype.Tuple1:
+		return isDecodableType(one.Inner1)
+	case *cltype.Tuple2:
+		return isDecodableType(one.Inner1) && isDecodableType(one.Inner2)
+	case *cltype.Tuple3:
+		return isDecodableType(one.Inner1) && isDecodableType(one.Inner2) && isDecodableType(one.Inner3)
+	case *cltype.Dynamic:
+		// Dynamic is the placeholder type used for the schema parsing only
+		return false
+	}
+
+	switch clType.GetTypeID() {
+	case cltype.TypeIDBool, cltype.TypeIDI32, cltype.TypeIDI64, cltype.TypeIDU8, cltype.TypeIDU32, cltype.TypeIDU64,
+		cltype.TypeIDU128, cltype.TypeIDU256, cltype.TypeIDU512, cltype.TypeIDUnit, cltype.TypeIDString,
+		cltype.TypeIDKey, cltype.TypeIDURef, cltype.TypeIDPublicKey:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,100 @@
+package ces
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2/utils/mocks"
+)
+
+var invalidSchemas = Schemas{
+	"Empty": {},
+	"Transfer": {
+		{ParamName: "from", ParamType: cltype.Key},
+		{ParamName: "from", ParamType: cltype.Key},
+		{ParamName: "data", ParamType: &cltype.List{ElementsType: &cltype.Option{Inner: cltype.Any}}},
+	},
+}
+
+func TestSchemasValidate(t *testing.T) {
+	metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	assert.NoError(t, metadata.Schemas.Validate())
+
+	err := invalidSchemas.Validate()
+	require.ErrorIs(t, err, ErrInvalidSchema)
+
+	var validationErr *SchemaValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Len(t, validationErr.Issues, 2)
+
+	// the empty event is the warning, it is not the reason of the validation error
+	assert.Equal(t, SchemaIssueDuplicateField, validationErr.Issues[0].Kind)
+	assert.Equal(t, "from", validationErr.Issues[0].FieldName)
+	assert.Equal(t, SchemaIssueUnsupportedType, validationErr.Issues[1].Kind)
+	assert.Equal(t, "data", validationErr.Issues[1].FieldName)
+
+	issues := invalidSchemas.Issues()
+	require.Len(t, issues, 3)
+	assert.Equal(t, SchemaIssueEmptyEvent, issues[0].Kind)
+	assert.Equal(t, "Empty", issues[0].EventName)
+	assert.True(t, issues[0].Kind.IsWarning())
+}
+
+func TestNewParserSchemaValidation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockedClient := mocks.NewMockStateReader(mockCtrl)
+
+	metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	invalidSchemasHex := encodeSchemasHex(invalidSchemas)
+
+	expectTestContract(t, mockedClient, metadata, metadata.EventsSchemaURef.String())
+	expectTestSchema(t, mockedClient, metadata.EventsSchemaURef.String(), invalidSchemasHex)
+	_, err := NewParser(mockedClient, []casper.Hash{metadata.ContractHash}, WithSchemaValidation(SchemaValidationRefuse))
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+
+	expectTestContract(t, mockedClient, metadata, metadata.EventsSchemaURef.String())
+	expectTestSchema(t, mockedClient, metadata.EventsSchemaURef.String(), invalidSchemasHex)
+	parser, err := NewParser(mockedClient, []casper.Hash{metadata.ContractHash}, WithSchemaValidation(SchemaValidationWarn))
+	require.NoError(t, err)
+
+	loaded, err := parser.contractMetadataByHash(metadata.ContractHash)
+	require.NoError(t, err)
+	assert.Len(t, loaded.SchemaIssues, 3)
+}
+
+func TestNewParserSchemaValidationCEP78(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockedClient := mocks.NewMockStateReader(mockCtrl)
+	metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+
+	// the standard CEP-78 schema declares VariablesSet and Migration events without fields
+	cep78Schemas := make(Schemas)
+	for _, definition := range knownTokenStandards {
+		if definition.standard != TokenStandardCEP78 {
+			continue
+		}
+		for _, event := range definition.events {
+			cep78Schemas[event.name] = event.variants[0]
+		}
+	}
+
+	expectTestContract(t, mockedClient, metadata, metadata.EventsSchemaURef.String())
+	expectTestSchema(t, mockedClient, metadata.EventsSchemaURef.String(), encodeSchemasHex(cep78Schemas))
+	parser, err := NewParser(mockedClient, []casper.Hash{metadata.ContractHash}, WithSchemaValidation(SchemaValidationRefuse))
+	require.NoError(t, err)
+
+	loaded, err := parser.contractMetadataByHash(metadata.ContractHash)
+	require.NoError(t, err)
+	assert.Equal(t, TokenStandardCEP78, loaded.TokenStandard)
+	assert.Empty(t, loaded.StandardDeviations)
+}
@@ -0,0 +1,237 @@
+package ces
+
+import (
+	"fmt"
+
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+)
+
+type TokenStandard int
+
+const (
+	TokenStandardUnknown TokenStandard = iota
+	// TokenStandardCEP18 is the CEP-18 fungible token
+	TokenStandardCEP18
+	// TokenStandardCEP78 is the CEP-78 NFT in the CES event mode
+	TokenStandardCEP78
+)
+
+func (s TokenStandard) String() string {
+	switch s {
+	case TokenStandardCEP18:
+		return "CEP-18"
+	case TokenStandardCEP78:
+		return "CEP-78"
+	default:
+		return "unknown"
+	}
+}
+
+type StandardDeviationKind int
+
+const (
+	// StandardDeviationMissingEvent reports required event of the standard absent in schemas
+	StandardDeviationMissingEvent StandardDeviationKind = iota + 1
+	// StandardDeviationSignatureMismatch reports event with the standard name but fields different from all known variants
+	StandardDeviationSignatureMismatch
+	// StandardDeviationExtraEvent reports event unknown to the standard
+	StandardDeviationExtraEvent
+)
+
+func (k StandardDeviationKind) String() string {
+	switch k {
+	case StandardDeviationMissingEvent:
+		return "missing_event"
+	case StandardDeviationSignatureMismatch:
+		return "signature_mismatch"
+	case StandardDeviationExtraEvent:
+		return "extra_event"
+	default:
+		return "unknown"
+	}
+}
+
+type (
+	StandardDeviation struct {
+		Kind      StandardDeviationKind
+		EventName EventName
+	}
+
+	// TokenClassification is the result of matching contract schemas against known token standards
+	TokenClassification struct {
+		Standard   TokenStandard
+		Deviations []StandardDeviation
+	}
+
+	standardEvent struct {
+		name     EventName
+		required bool
+		// variants lists accepted field sets of the event across standard releases
+		variants [][]SchemaData
+	}
+
+	tokenStandardDefinition struct {
+		standard TokenStandard
+		events   []standardEvent
+	}
+)
+
+func (d StandardDeviation) String() string {
+	return fmt.Sprintf("%s: %s", d.Kind, d.EventName)
+}
+
+var knownTokenStandards = []tokenStandardDefinition{
+	{
+		standard: TokenStandardCEP18,
+		events: []standardEvent{
+			{name: "Mint", required: true, variants: [][]SchemaData{
+				{{"recipient", cltype.Key}, {"amount", cltype.UInt256}},
+			}},
+			{name: "Burn", required: true, variants: [][]SchemaData{
+				{{"owner", cltype.Key}, {"amount", cltype.UInt256}},
+			}},
+			{name: "SetAllowance", required: true, variants: [][]SchemaData{
+				{{"owner", cltype.Key}, {"spender", cltype.Key}, {"allowance", cltype.UInt256}},
+			}},
+			{name: "IncreaseAllowance", required: true, variants: [][]SchemaData{
+				{{"owner", cltype.Key}, {"spender", cltype.Key}, {"allowance", cltype.UInt256}, {"inc_by", cltype.UInt256}},
+			}},
+			{name: "DecreaseAllowance", required: true, variants: [][]SchemaData{
+				{{"owner", cltype.Key}, {"spender", cltype.Key}, {"allowance", cltype.UInt256}, {"decr_by", cltype.UInt256}},
+			}},
+			{name: "Transfer", required: true, variants: [][]SchemaData{
+				{{"sender", cltype.Key}, {"recipient", cltype.Key}, {"amount", cltype.UInt256}},
+			}},
+			{name: "TransferFrom", required: true, variants: [][]SchemaData{
+				{{"spender", cltype.Key}, {"owner", cltype.Key}, {"recipient", cltype.Key}, {"amount", cltype.UInt256}},
+			}},
+			{name: "ChangeSecurity", variants: [][]SchemaData{
+				{{"admin", cltype.Key}, {"sec_change_map", &cltype.Map{Key: cltype.Key, Val: cltype.UInt8}}},
+			}},
+		},
+	},
+	{
+		standard: TokenStandardCEP78,
+		events: []standardEvent{
+			{name: "Mint", required: true, variants: [][]SchemaData{
+				{{"recipient", cltype.Key}, {"token_id", cltype.String}, {"data", cltype.String}},
+			}},
+			{name: "Burn", required: true, variants: [][]SchemaData{
+				{{"owner", cltype.Key}, {"token_id", cltype.String}, {"burner", cltype.Key}},
+				// releases before burner was added
+				{{"owner", cltype.Key}, {"token_id", cltype.String}},
+			}},
+			{name: "Approval", required: true, variants: [][]SchemaData{
+				{{"owner", cltype.Key}, {"spender", cltype.Key}, {"token_id", cltype.String}},
+				// releases before operator was renamed to spender
+				{{"owner", cltype.Key}, {"operator", cltype.Key}, {"token_id", cltype.String}},
+			}},
+			{name: "ApprovalRevoked", variants: [][]SchemaData{
+				{{"owner", cltype.Key}, {"token_id", cltype.String}},
+			}},
+			{name: "ApprovalForAll", required: true, variants: [][]SchemaData{
+				{{"owner", cltype.Key}, {"operator", cltype.Key}},
+			}},
+			{name: "RevokedForAll", variants: [][]SchemaData{
+				{{"owner", cltype.Key}, {"operator", cltype.Key}},
+			}},
+			{name: "Transfer", required: true, variants: [][]SchemaData{
+				{{"owner", cltype.Key}, {"spender", &cltype.Option{Inner: cltype.Key}}, {"recipient", cltype.Key}, {"token_id", cltype.String}},
+			}},
+			{name: "MetadataUpdated", required: true, variants: [][]SchemaData{
+				{{"token_id", cltype.String}, {"data", cltype.String}},
+			}},
+			{name: "VariablesSet", variants: [][]SchemaData{{}}},
+			{name: "Migration", variants: [][]SchemaData{{}}},
+		},
+	},
+}
+
+// ClassifySchemas match schemas against known token standards and return the standard with the most exactly matched
+// events. The contract is classified if at least half of the standard required events match exactly, all other
+// differences are reported as deviations. Contracts emitting events in the CEP-47 compatible mode have no CES schemas
+// and stay unknown.
+func ClassifySchemas(schemas Schemas) TokenClassification {
+	fingerprints := schemas.EventFingerprints()
+
+	var (
+		best        TokenClassification
+		bestMatches int
+	)
+	for _, definition := range knownTokenStandards {
+		matches, requiredMatches, requiredTotal := 0, 0, 0
+		for _, event := range definition.events {
+			if event.required {
+				requiredTotal++
+			}
+			if event.matches(fingerprints) {
+				matches++
+				if event.required {
+					requiredMatches++
+				}
+			}
+		}
+
+		if requiredMatches*2 < requiredTotal || matches <= bestMatches {
+			continue
+		}
+
+		bestMatches = matches
+		best = TokenClassification{
+			Standard:   definition.standard,
+			Deviations: definition.deviations(schemas, fingerprints),
+		}
+	}
+
+	return best
+}
+
+func (e standardEvent) matches(fingerprints map[EventName]SchemaFingerprint) bool {
+	fingerprint, ok := fingerprints[e.name]
+	if !ok {
+		return false
+	}
+
+	for _, variant := range e.variants {
+		if EventSignatureFingerprint(e.name, variant) == fingerprint {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deviations return differences of schemas from the standard ordered by the event name
+func (d tokenStandardDefinition) deviations(schemas Schemas, fingerprints map[EventName]SchemaFingerprint) []StandardDeviation {
+	known := make(map[EventName]standardEvent, len(d.events))
+	for _, event := range d.events {
+		known[event.name] = event
+	}
+
+	var deviations []StandardDeviation
+	for _, name := range sortedEventNames(schemas, knownEventsSchemas(d.events)) {
+		event, isKnown := known[name]
+		_, isPresent := fingerprints[name]
+
+		switch {
+		case !isKnown:
+			deviations = append(deviations, StandardDeviation{Kind: StandardDeviationExtraEvent, EventName: name})
+		case !isPresent:
+			if event.required {
+				deviations = append(deviations, StandardDeviation{Kind: StandardDeviationMissingEvent, EventName: name})
+			}
+		case !event.matches(fingerprints):
+			deviations = append(deviations, StandardDeviation{Kind: StandardDeviationSignatureMismatch, EventName: name})
+		}
+	}
+
+	return deviations
+}
+
+func knownEventsSchemas(events []standardEvent) Schemas {
+	schemas := make(Schemas, len(events))
+	for _, event := range events {
+		schemas[event.name] = event.variants[0]
+	}
+	return schemas
+}
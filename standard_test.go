@@ -0,0 +1,53 @@
+package ces
+
+import (
+	"testing"
+
+	"github.com/make-software/casper-go-sdk/v2/types/clvalue/cltype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifySchemas(t *testing.T) {
+	t.Run("CEP-18", func(t *testing.T) {
+		schemas := Schemas{
+			"Mint":              {{"recipient", cltype.Key}, {"amount", cltype.UInt256}},
+			"Burn":              {{"owner", cltype.Key}, {"amount", cltype.UInt256}},
+			"SetAllowance":      {{"owner", cltype.Key}, {"spender", cltype.Key}, {"allowance", cltype.UInt256}},
+			"IncreaseAllowance": {{"owner", cltype.Key}, {"spender", cltype.Key}, {"allowance", cltype.UInt256}, {"inc_by", cltype.UInt256}},
+			"DecreaseAllowance": {{"owner", cltype.Key}, {"spender", cltype.Key}, {"allowance", cltype.UInt256}, {"decr_by", cltype.UInt256}},
+			"Transfer":          {{"sender", cltype.Key}, {"recipient", cltype.Key}, {"amount", cltype.UInt256}},
+			"TransferFrom":      {{"spender", cltype.Key}, {"owner", cltype.Key}, {"recipient", cltype.Key}, {"amount", cltype.UInt256}},
+		}
+
+		classification := ClassifySchemas(schemas)
+		assert.Equal(t, TokenStandardCEP18, classification.Standard)
+		assert.Empty(t, classification.Deviations)
+	})
+
+	t.Run("CEP-78 with deviations", func(t *testing.T) {
+		schemas := Schemas{
+			"Mint":            {{"recipient", cltype.Key}, {"token_id", cltype.String}, {"data", cltype.String}},
+			"Burn":            {{"owner", cltype.Key}, {"token_id", cltype.String}},
+			"Approval":        {{"owner", cltype.Key}, {"spender", cltype.Key}, {"token_id", cltype.String}},
+			"ApprovalForAll":  {{"owner", cltype.Key}, {"operator", cltype.Key}},
+			"Transfer":        {{"owner", cltype.Key}, {"recipient", cltype.Key}, {"token_id", cltype.String}},
+			"CustomBroadcast": {{"message", cltype.String}},
+		}
+
+		classification := ClassifySchemas(schemas)
+		assert.Equal(t, TokenStandardCEP78, classification.Standard)
+		assert.Equal(t, []StandardDeviation{
+			{Kind: StandardDeviationExtraEvent, EventName: "CustomBroadcast"},
+			{Kind: StandardDeviationMissingEvent, EventName: "MetadataUpdated"},
+			{Kind: StandardDeviationSignatureMismatch, EventName: "Transfer"},
+		}, classification.Deviations)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+		metadata.TokenStandard = TokenStandardCEP18
+		metadata.SetSchemas(metadata.Schemas)
+		assert.Equal(t, TokenStandardUnknown, metadata.TokenStandard)
+		assert.Empty(t, metadata.StandardDeviations)
+	})
+}
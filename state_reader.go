@@ -0,0 +1,41 @@
+package ces
+
+import (
+	"context"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/rpc"
+)
+
+type (
+	// StateReader is the part of the node API EventParser reads contracts, schemas and events with.
+	// casper.RPCClient implements it, other backends such as snapshots, caches or archive databases may implement it
+	// to serve the parser without the node.
+	StateReader interface {
+		GetStateRootHashLatest(ctx context.Context) (rpc.ChainGetStateRootHashResult, error)
+		QueryGlobalStateByStateHash(ctx context.Context, stateRootHash *string, key string, path []string) (rpc.QueryGlobalStateResult, error)
+		GetDictionaryItem(ctx context.Context, stateRootHash *string, uref, key string) (rpc.StateGetDictionaryResult, error)
+	}
+
+	// rpcStateReader exposes only StateReader methods of casper.RPCClient
+	rpcStateReader struct {
+		client casper.RPCClient
+	}
+)
+
+// NewRPCStateReader adapt casper.RPCClient to StateReader
+func NewRPCStateReader(client casper.RPCClient) StateReader {
+	return &rpcStateReader{client: client}
+}
+
+func (r *rpcStateReader) GetStateRootHashLatest(ctx context.Context) (rpc.ChainGetStateRootHashResult, error) {
+	return r.client.GetStateRootHashLatest(ctx)
+}
+
+func (r *rpcStateReader) QueryGlobalStateByStateHash(ctx context.Context, stateRootHash *string, key string, path []string) (rpc.QueryGlobalStateResult, error) {
+	return r.client.QueryGlobalStateByStateHash(ctx, stateRootHash, key, path)
+}
+
+func (r *rpcStateReader) GetDictionaryItem(ctx context.Context, stateRootHash *string, uref, key string) (rpc.StateGetDictionaryResult, error) {
+	return r.client.GetDictionaryItem(ctx, stateRootHash, uref, key)
+}
@@ -0,0 +1,44 @@
+package ces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2/utils/mocks"
+)
+
+func TestNewRPCStateReader(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockedClient := mocks.NewMockClient(mockCtrl)
+	reader := NewRPCStateReader(mockedClient)
+
+	// other methods of casper.RPCClient are not reachable through the adapter
+	_, ok := reader.(casper.RPCClient)
+	assert.False(t, ok)
+
+	stateRootHash, err := casper.NewHash("ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	require.NoError(t, err)
+	rootHash := stateRootHash.ToHex()
+
+	mockedClient.EXPECT().GetStateRootHashLatest(context.Background()).Return(casper.ChainGetStateRootHashResult{StateRootHash: stateRootHash}, nil)
+	mockedClient.EXPECT().QueryGlobalStateByStateHash(context.Background(), &rootHash, "hash-1", []string{"__events"}).Return(rpc.QueryGlobalStateResult{}, nil)
+	mockedClient.EXPECT().GetDictionaryItem(context.Background(), &rootHash, "uref-1", "2").Return(rpc.StateGetDictionaryResult{}, nil)
+
+	result, err := reader.GetStateRootHashLatest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, stateRootHash, result.StateRootHash)
+
+	_, err = reader.QueryGlobalStateByStateHash(context.Background(), &rootHash, "hash-1", []string{"__events"})
+	require.NoError(t, err)
+
+	_, err = reader.GetDictionaryItem(context.Background(), &rootHash, "uref-1", "2")
+	require.NoError(t, err)
+}
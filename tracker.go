@@ -0,0 +1,235 @@
+package ces
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/make-software/casper-go-sdk/v2/casper"
+)
+
+// DefaultMaxGapFill is the maximal number of events EventTracker reads to fill a single gap, see WithMaxGapFill
+const DefaultMaxGapFill = 1000
+
+var ErrGapTooLarge = errors.New("error: event id gap exceeds the gap fill limit")
+
+type EventIDNotificationKind int
+
+const (
+	// EventIDGap reports that one or more event ids between the last seen and the current one were not observed
+	EventIDGap EventIDNotificationKind = iota + 1
+	// EventIDDuplicate reports that the event id was already observed as the last one
+	EventIDDuplicate
+	// EventIDOutOfOrder reports event id lower than the last seen one
+	EventIDOutOfOrder
+)
+
+func (k EventIDNotificationKind) String() string {
+	switch k {
+	case EventIDGap:
+		return "gap"
+	case EventIDDuplicate:
+		return "duplicate"
+	case EventIDOutOfOrder:
+		return "out_of_order"
+	default:
+		return "unknown"
+	}
+}
+
+type (
+	// EventIDNotification describe a violation of sequential contract event ids
+	EventIDNotification struct {
+		Kind         EventIDNotificationKind
+		ContractHash casper.Hash
+		// EventID is the event id which caused the notification
+		EventID uint
+		// LastEventID is the last event id seen before EventID
+		LastEventID uint
+		// MissingFromID and MissingToID is the inclusive range of not observed ids, set for EventIDGap only
+		MissingFromID uint
+		MissingToID   uint
+		// Recovered contains events read from the __events dictionary to fill the gap
+		Recovered []ParseResult
+		// FillError is set if the gap filling was enabled but failed
+		FillError error
+	}
+
+	// EventTracker follows the last seen event id per contract and reports gaps, duplicates and out-of-order ids
+	EventTracker struct {
+		mu           sync.Mutex
+		lastEventIDs map[casper.Hash]uint
+		// skippedEventIDs are ids passed to Skip ahead of the last event id, they are not taken for gaps
+		skippedEventIDs map[casper.Hash]map[uint]struct{}
+		// gapFiller is used to read missing events from the global state, gaps are not filled if nil
+		gapFiller  *EventParser
+		maxGapFill uint
+	}
+
+	EventTrackerOption func(*EventTracker)
+)
+
+// WithGapFilling makes EventTracker read missing events from the contract __events dictionary using the provided parser
+func WithGapFilling(parser *EventParser) EventTrackerOption {
+	return func(t *EventTracker) {
+		t.gapFiller = parser
+	}
+}
+
+// WithMaxGapFill limit the number of events read to fill a single gap, larger gaps are reported with
+// ErrGapTooLarge FillError and are not filled. DefaultMaxGapFill is used by default.
+func WithMaxGapFill(max uint) EventTrackerOption {
+	return func(t *EventTracker) {
+		t.maxGapFill = max
+	}
+}
+
+func NewEventTracker(opts ...EventTrackerOption) *EventTracker {
+	tracker := &EventTracker{
+		lastEventIDs:    make(map[casper.Hash]uint),
+		skippedEventIDs: make(map[casper.Hash]map[uint]struct{}),
+		maxGapFill:      DefaultMaxGapFill,
+	}
+
+	for _, opt := range opts {
+		opt(tracker)
+	}
+
+	return tracker
+}
+
+// SetLastEventID set the last processed event id of the contract, e.g. restored from the storage on start
+func (t *EventTracker) SetLastEventID(contractHash casper.Hash, eventID uint) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.lastEventIDs[contractHash] = eventID
+}
+
+// LastEventID return the last seen event id of the contract
+func (t *EventTracker) LastEventID(contractHash casper.Hash) (uint, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	eventID, ok := t.lastEventIDs[contractHash]
+	return eventID, ok
+}
+
+// Skip mark the event id of the contract as seen without tracking its result, e.g. the event dropped by EventFilter,
+// see WithFilteredEventHandler. Ids skipped ahead of the tracked results are not reported as gaps.
+func (t *EventTracker) Skip(contractHash casper.Hash, eventID uint) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lastEventID, ok := t.lastEventIDs[contractHash]
+	switch {
+	case !ok || eventID == lastEventID+1:
+		t.lastEventIDs[contractHash] = eventID
+		t.advanceSkipped(contractHash)
+	case eventID > lastEventID+1:
+		skipped, ok := t.skippedEventIDs[contractHash]
+		if !ok {
+			skipped = make(map[uint]struct{})
+			t.skippedEventIDs[contractHash] = skipped
+		}
+		skipped[eventID] = struct{}{}
+	}
+}
+
+// advanceSkipped move the last event id of the contract over the skipped ids following it and forget the passed ones
+func (t *EventTracker) advanceSkipped(contractHash casper.Hash) {
+	skipped, ok := t.skippedEventIDs[contractHash]
+	if !ok {
+		return
+	}
+
+	lastEventID := t.lastEventIDs[contractHash]
+	for {
+		if _, ok = skipped[lastEventID+1]; !ok {
+			break
+		}
+		lastEventID++
+	}
+	t.lastEventIDs[contractHash] = lastEventID
+
+	for eventID := range skipped {
+		if eventID <= lastEventID {
+			delete(skipped, eventID)
+		}
+	}
+	if len(skipped) == 0 {
+		delete(t.skippedEventIDs, contractHash)
+	}
+}
+
+// Track accept parse results in the order they were emitted and return notifications about event id violations.
+// The first event of the contract is taken as is unless the last event id was set with SetLastEventID.
+// Gaps are filled after the last event ids are updated, the tracker is not locked while the missing events are read.
+func (t *EventTracker) Track(ctx context.Context, results []ParseResult) []EventIDNotification {
+	notifications := t.track(results)
+	if t.gapFiller == nil {
+		return notifications
+	}
+
+	for i := range notifications {
+		notification := &notifications[i]
+		if notification.Kind != EventIDGap {
+			continue
+		}
+
+		if notification.MissingToID-notification.MissingFromID >= t.maxGapFill {
+			notification.FillError = ErrGapTooLarge
+			continue
+		}
+
+		notification.Recovered, notification.FillError = t.gapFiller.FetchEvents(ctx, notification.ContractHash, notification.MissingFromID, notification.MissingToID)
+	}
+
+	return notifications
+}
+
+// track update the last event ids and return notifications without filling the gaps
+func (t *EventTracker) track(results []ParseResult) []EventIDNotification {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var notifications []EventIDNotification
+	for _, result := range results {
+		contractHash := result.Event.ContractHash
+		eventID := result.Event.EventID
+
+		lastEventID, ok := t.lastEventIDs[contractHash]
+		if ok && eventID > lastEventID+1 {
+			t.advanceSkipped(contractHash)
+			lastEventID = t.lastEventIDs[contractHash]
+		}
+		if !ok || eventID == lastEventID+1 {
+			t.lastEventIDs[contractHash] = eventID
+			t.advanceSkipped(contractHash)
+			continue
+		}
+
+		notification := EventIDNotification{
+			ContractHash: contractHash,
+			EventID:      eventID,
+			LastEventID:  lastEventID,
+		}
+
+		switch {
+		case eventID == lastEventID:
+			notification.Kind = EventIDDuplicate
+		case eventID < lastEventID:
+			notification.Kind = EventIDOutOfOrder
+		default:
+			notification.Kind = EventIDGap
+			notification.MissingFromID = lastEventID + 1
+			notification.MissingToID = eventID - 1
+			t.lastEventIDs[contractHash] = eventID
+			t.advanceSkipped(contractHash)
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications
+}
@@ -0,0 +1,171 @@
+package ces
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/make-software/casper-go-sdk/v2/casper"
+	"github.com/make-software/casper-go-sdk/v2/rpc"
+	"github.com/make-software/casper-go-sdk/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/make-software/ces-go-parser/v2/utils/mocks"
+)
+
+func TestEventTracker(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockedClient := mocks.NewMockStateReader(mockCtrl)
+
+	contractHash, err := casper.NewHash("ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+	require.NoError(t, err)
+
+	eventsURef, err := casper.NewUref("uref-d2263e86f497f42e405d5d1390aa3c1a8bfc35f3699fdc3be806a5cfe139dac9-007")
+	require.NoError(t, err)
+
+	schemaBytes, err := hex.DecodeString(votingSchemaHex)
+	require.NoError(t, err)
+
+	schemas, err := NewSchemasFromBytes(schemaBytes)
+	require.NoError(t, err)
+
+	eventParser := &EventParser{
+		stateReader: mockedClient,
+		contractsMetadata: map[string]ContractMetadata{
+			eventsURef.String(): {
+				Schemas:      schemas,
+				ContractHash: contractHash,
+				EventsURef:   eventsURef,
+			},
+		},
+	}
+
+	newResult := func(eventID uint) ParseResult {
+		return ParseResult{Event: Event{ContractHash: contractHash, EventID: eventID}}
+	}
+
+	t.Run("Test sequential ids", func(t *testing.T) {
+		tracker := NewEventTracker()
+
+		notifications := tracker.Track(context.Background(), []ParseResult{newResult(0), newResult(1), newResult(2)})
+		assert.Empty(t, notifications)
+
+		lastEventID, ok := tracker.LastEventID(contractHash)
+		assert.True(t, ok)
+		assert.Equal(t, uint(2), lastEventID)
+	})
+
+	t.Run("Test duplicate and out of order ids", func(t *testing.T) {
+		tracker := NewEventTracker()
+		tracker.SetLastEventID(contractHash, 5)
+
+		notifications := tracker.Track(context.Background(), []ParseResult{newResult(5), newResult(3)})
+		require.Len(t, notifications, 2)
+		assert.Equal(t, EventIDDuplicate, notifications[0].Kind)
+		assert.Equal(t, EventIDOutOfOrder, notifications[1].Kind)
+		assert.Equal(t, uint(5), notifications[1].LastEventID)
+
+		lastEventID, _ := tracker.LastEventID(contractHash)
+		assert.Equal(t, uint(5), lastEventID)
+	})
+
+	t.Run("Test gap filling", func(t *testing.T) {
+		var arg casper.Argument
+		err = json.Unmarshal([]byte(fmt.Sprintf(`{"cl_type": "Any", "bytes": "%s"}`, ballotCastEventHex)), &arg)
+		require.NoError(t, err)
+
+		mockedClient.EXPECT().GetDictionaryItem(context.Background(), nil, eventsURef.String(), "2").Return(rpc.StateGetDictionaryResult{
+			StoredValue: casper.StoredValue{
+				CLValue: &arg,
+			},
+		}, nil)
+
+		tracker := NewEventTracker(WithGapFilling(eventParser))
+		tracker.SetLastEventID(contractHash, 1)
+
+		notifications := tracker.Track(context.Background(), []ParseResult{newResult(3)})
+		require.Len(t, notifications, 1)
+		assert.Equal(t, EventIDGap, notifications[0].Kind)
+		assert.Equal(t, uint(2), notifications[0].MissingFromID)
+		assert.Equal(t, uint(2), notifications[0].MissingToID)
+		require.NoError(t, notifications[0].FillError)
+		require.Len(t, notifications[0].Recovered, 1)
+		assert.NoError(t, notifications[0].Recovered[0].Error)
+		assert.Equal(t, "BallotCast", notifications[0].Recovered[0].Event.Name)
+		assert.Equal(t, uint(2), notifications[0].Recovered[0].Event.EventID)
+		assert.Equal(t, contractHash, notifications[0].Recovered[0].Event.ContractHash)
+	})
+
+	t.Run("Test gap fill limit", func(t *testing.T) {
+		// no RPC calls are expected, the gap exceeds the limit
+		tracker := NewEventTracker(WithGapFilling(eventParser), WithMaxGapFill(10))
+		tracker.SetLastEventID(contractHash, 1)
+
+		notifications := tracker.Track(context.Background(), []ParseResult{newResult(1_000_000)})
+		require.Len(t, notifications, 1)
+		assert.Equal(t, EventIDGap, notifications[0].Kind)
+		assert.ErrorIs(t, notifications[0].FillError, ErrGapTooLarge)
+		assert.Empty(t, notifications[0].Recovered)
+
+		lastEventID, _ := tracker.LastEventID(contractHash)
+		assert.Equal(t, uint(1_000_000), lastEventID)
+	})
+
+	t.Run("Test filtered events", func(t *testing.T) {
+		data, err := os.ReadFile("./utils/fixtures/deploys/voting_created.json")
+		require.NoError(t, err)
+
+		var fixture struct {
+			ExecutionResults []types.DeployExecutionResult `json:"execution_results"`
+		}
+		require.NoError(t, json.Unmarshal(data, &fixture))
+		executionResult := types.DeployExecutionInfoFromV1(fixture.ExecutionResults, nil).ExecutionResult
+
+		// no RPC calls are expected, BallotCast event 2 is dropped by the filter and is not a gap
+		tracker := NewEventTracker(WithGapFilling(eventParser))
+		tracker.SetLastEventID(contractHash, 1)
+
+		metadata := newTestContractMetadata(t, "ea0c001d969da098fefec42b141db88c74c5682e49333ded78035540a0b4f0bc")
+		filteringParser := &EventParser{
+			stateReader:       mockedClient,
+			contractsMetadata: map[string]ContractMetadata{metadata.EventsURef.String(): metadata},
+		}
+		WithEventFilter(EventFilter{EventNames: []EventName{"SimpleVotingCreated"}})(filteringParser)
+		WithFilteredEventHandler(tracker.Skip)(filteringParser)
+
+		results, err := filteringParser.ParseExecutionResults(executionResult)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(3), results[0].Event.EventID)
+
+		assert.Empty(t, tracker.Track(context.Background(), results))
+
+		lastEventID, _ := tracker.LastEventID(contractHash)
+		assert.Equal(t, uint(3), lastEventID)
+	})
+
+	t.Run("Test skipped ids", func(t *testing.T) {
+		tracker := NewEventTracker()
+		tracker.SetLastEventID(contractHash, 1)
+
+		// ids skipped ahead of the tracked results close the gaps, the unknown id 6 is still reported
+		tracker.Skip(contractHash, 3)
+		tracker.Skip(contractHash, 5)
+		notifications := tracker.Track(context.Background(), []ParseResult{newResult(2), newResult(4), newResult(7)})
+		require.Len(t, notifications, 1)
+		assert.Equal(t, EventIDGap, notifications[0].Kind)
+		assert.Equal(t, uint(6), notifications[0].MissingFromID)
+		assert.Equal(t, uint(6), notifications[0].MissingToID)
+
+		tracker.Skip(contractHash, 8)
+		lastEventID, _ := tracker.LastEventID(contractHash)
+		assert.Equal(t, uint(8), lastEventID)
+	})
+}